@@ -0,0 +1,3 @@
+package arrays
+
+var ToSlice = toSlice
@@ -5,14 +5,18 @@ import (
 	"reflect"
 )
 
+// toSlice returns the elements of the slice, or of the slice which the pointers point to.
 func toSlice(input interface{}) ([]interface{}, error) {
-	switch reflect.TypeOf(input).Kind() {
+	s := reflect.ValueOf(input)
+	for s.Kind() == reflect.Ptr && !s.IsNil() {
+		s = s.Elem()
+	}
+	switch s.Kind() {
 	case reflect.Slice:
-		s := reflect.ValueOf(input)
 		l := s.Len()
 		slice := make([]interface{}, l)
 		for i := 0; i < l; i++ {
-			slice[i] = s.Index(i)
+			slice[i] = s.Index(i).Interface()
 		}
 		return slice, nil
 	default:
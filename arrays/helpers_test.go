@@ -0,0 +1,50 @@
+package arrays_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+)
+
+func TestToSlice(t *testing.T) {
+	type Case struct {
+		Message  string
+		Input    interface{}
+		Expected []interface{}
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message:  "slice",
+			Input:    []int{1, 2},
+			Expected: []interface{}{1, 2},
+		},
+		{
+			Message:  "pointer to slice",
+			Input:    &[]string{"a", "b"},
+			Expected: []interface{}{"a", "b"},
+		},
+		{
+			Message:  "slice of interfaces",
+			Input:    []interface{}{1, "a", nil},
+			Expected: []interface{}{1, "a", nil},
+		},
+		{
+			Message: "non-slice",
+			Input:   1,
+			Error:   arrays.TypeError{Message: "int should be slice"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := arrays.ToSlice(c.Input)
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("%s: expected error %+v, but actual %+v", c.Message, c.Error, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.Expected) {
+			t.Errorf("%s: expected %#v, but actual %#v", c.Message, c.Expected, actual)
+		}
+	}
+}
@@ -1,5 +1,11 @@
 package arrays
 
-type Validator interface {
-	Validate(input []interface{})
-}
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates a slice or an array.
+type Validator = validators.Validator[interface{}]
+
+var (
+	_ Validator = MaxItemsValidator{}
+	_ Validator = MinItemsValidator{}
+)
@@ -21,7 +21,7 @@ type MaxItemsValidationError struct {
 }
 
 func (err MaxItemsValidationError) Error() string {
-	return fmt.Sprintf("the length of %v should be less than %d",
+	return fmt.Sprintf("the length of %v should be less than or equal to %d",
 		err.Input, err.Definition.MaxItems)
 }
 
@@ -146,6 +146,24 @@ func TestValidateOfMaxItemsValidator(t *testing.T) {
 				Definition: def,
 			},
 		},
+		{
+			Message: "same length of pointer to slice",
+			Input:   &[]int{1, 2},
+			Error:   nil,
+		},
+		{
+			Message: "greater length of pointer to slice",
+			Input:   &[]int{1, 2, 3},
+			Error: &arrays.MaxItemsValidationError{
+				Input:      &[]int{1, 2, 3},
+				Definition: def,
+			},
+		},
+		{
+			Message: "nil pointer to slice",
+			Input:   (*[]int)(nil),
+			Error:   arrays.TypeError{Message: "*[]int should be slice"},
+		},
 	}
 
 	for _, c := range cases {
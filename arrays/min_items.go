@@ -21,7 +21,7 @@ type MinItemsValidationError struct {
 }
 
 func (err MinItemsValidationError) Error() string {
-	return fmt.Sprintf("the length of %v should be less than %d",
+	return fmt.Sprintf("the length of %v should be greater than or equal to %d",
 		err.Input, err.Definition.MinItems)
 }
 
@@ -0,0 +1,50 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ConstDefinitionNotNumberError = errors.New("the Const should be a number")
+
+type ConstValidator struct {
+	definition ConstValidatorDefinition
+	value      *big.Rat
+}
+
+type ConstValidatorDefinition struct {
+	Const json.Number `json:"const"`
+}
+
+type ConstValidationError struct {
+	Definition ConstValidatorDefinition `json:"definition"`
+	Input      interface{}              `json:"input"`
+}
+
+func (err ConstValidationError) Error() string {
+	return fmt.Sprintf("input value %v should be %s", err.Input, err.Definition.Const)
+}
+
+func NewConstValidator(definition ConstValidatorDefinition) (ConstValidator, error) {
+	value, ok := parseNumber(definition.Const)
+	if !ok {
+		return ConstValidator{}, ConstDefinitionNotNumberError
+	}
+	return ConstValidator{definition, value}, nil
+}
+
+func (c ConstValidator) Validate(input interface{}) error {
+	r, ok := Rat(input)
+	if !ok {
+		return &TypeError{input}
+	}
+	if r.Cmp(c.value) == 0 {
+		return nil
+	}
+	return &ConstValidationError{
+		c.definition,
+		input,
+	}
+}
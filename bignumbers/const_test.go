@@ -0,0 +1,58 @@
+package bignumbers_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+func TestValidateOfConstValidator(t *testing.T) {
+	if _, err := bignumbers.NewConstValidator(bignumbers.ConstValidatorDefinition{Const: "NaN"}); err != bignumbers.ConstDefinitionNotNumberError {
+		t.Errorf("expected %v, but actual %v", bignumbers.ConstDefinitionNotNumberError, err)
+	}
+
+	def := bignumbers.ConstValidatorDefinition{Const: "1e20"}
+	v, err := bignumbers.NewConstValidator(def)
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+
+	type Case struct {
+		Message string
+		Input   interface{}
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "same json.Number",
+			Input:   json.Number("100000000000000000000"),
+			Error:   nil,
+		},
+		{
+			Message: "same big.Int",
+			Input:   new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil),
+			Error:   nil,
+		},
+		{
+			Message: "different json.Number",
+			Input:   json.Number("100000000000000000001"),
+			Error: &bignumbers.ConstValidationError{
+				Definition: def,
+				Input:      json.Number("100000000000000000001"),
+			},
+		},
+		{
+			Message: "json.Number of too large exponent",
+			Input:   json.Number("1e100000"),
+			Error:   &bignumbers.TypeError{Input: json.Number("1e100000")},
+		},
+	}
+	for _, c := range cases {
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	EnumDefinitionEmptyError       = errors.New("the Enum should have at least one element")
+	EnumDefinitionDuplicationError = errors.New("the elements of Enum shouldn't be duplicated")
+	EnumDefinitionNotNumberError   = errors.New("the elements of Enum should be numbers")
+)
+
+type EnumValidator struct {
+	definition EnumValidatorDefinition
+	enum       []*big.Rat
+}
+
+// EnumValidatorDefinition is the numbers which are compared by their values,
+// so that 1 and 1.0 are duplicated.
+type EnumValidatorDefinition struct {
+	Enum []json.Number `json:"enum"`
+}
+
+type EnumValidationError struct {
+	Definition EnumValidatorDefinition `json:"definition"`
+	Input      interface{}             `json:"input"`
+}
+
+func (err EnumValidationError) Error() string {
+	return fmt.Sprintf("input value %v doesn't exist in %v", err.Input, err.Definition.Enum)
+}
+
+func NewEnumValidator(def EnumValidatorDefinition) (EnumValidator, error) {
+	if len(def.Enum) == 0 {
+		return EnumValidator{}, EnumDefinitionEmptyError
+	}
+	enum := make([]*big.Rat, len(def.Enum))
+	for i, n := range def.Enum {
+		r, ok := parseNumber(n)
+		if !ok {
+			return EnumValidator{}, EnumDefinitionNotNumberError
+		}
+		for _, e := range enum[:i] {
+			if e.Cmp(r) == 0 {
+				return EnumValidator{}, EnumDefinitionDuplicationError
+			}
+		}
+		enum[i] = r
+	}
+	return EnumValidator{def, enum}, nil
+}
+
+func (v EnumValidator) Validate(input interface{}) error {
+	r, ok := Rat(input)
+	if !ok {
+		return &TypeError{input}
+	}
+	for _, e := range v.enum {
+		if r.Cmp(e) == 0 {
+			return nil
+		}
+	}
+	return &EnumValidationError{
+		v.definition,
+		input,
+	}
+}
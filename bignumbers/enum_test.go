@@ -0,0 +1,83 @@
+package bignumbers_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+func TestNewEnumValidator(t *testing.T) {
+	type Case struct {
+		Message    string
+		Definition bignumbers.EnumValidatorDefinition
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "empty slice",
+			Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{}},
+			Error:      bignumbers.EnumDefinitionEmptyError,
+		},
+		{
+			Message:    "multi elements",
+			Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{"18446744073709551615", "18446744073709551616"}},
+			Error:      nil,
+		},
+		{
+			Message:    "elements of the same value",
+			Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{"1", "1.0"}},
+			Error:      bignumbers.EnumDefinitionDuplicationError,
+		},
+		{
+			Message:    "element which is not a number",
+			Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{"1", "one"}},
+			Error:      bignumbers.EnumDefinitionNotNumberError,
+		},
+	}
+	for _, c := range cases {
+		if _, err := bignumbers.NewEnumValidator(c.Definition); err != c.Error {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestValidateOfEnumValidator(t *testing.T) {
+	def := bignumbers.EnumValidatorDefinition{Enum: []json.Number{"18446744073709551615", "0.5"}}
+	v, err := bignumbers.NewEnumValidator(def)
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+
+	type Case struct {
+		Message string
+		Input   interface{}
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "uint64 in enum",
+			Input:   uint64(18446744073709551615),
+			Error:   nil,
+		},
+		{
+			Message: "float64 in enum",
+			Input:   0.5,
+			Error:   nil,
+		},
+		{
+			Message: "json.Number not in enum",
+			Input:   json.Number("18446744073709551614"),
+			Error: &bignumbers.EnumValidationError{
+				Definition: def,
+				Input:      json.Number("18446744073709551614"),
+			},
+		},
+	}
+	for _, c := range cases {
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// maxExponent is the largest exponent of the json.Number which is converted
+// exactly, so that a short number such as 1e1000000000 can't exhaust memory.
+const maxExponent = 10000
+
+var rNumber = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE]([+-]?[0-9]+))?$`)
+
+// TypeError for the inputs which are not numbers
+type TypeError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err TypeError) Error() string {
+	return fmt.Sprintf("%T isn't a number", err.Input)
+}
+
+// Rat returns the exact value of input, which is a json.Number, an integer
+// or a float of any Go type, or a big.Int, big.Rat or big.Float and their
+// pointers. The ok reports whether input is a finite number.
+func Rat(input interface{}) (r *big.Rat, ok bool) {
+	switch t := input.(type) {
+	case json.Number:
+		return parseNumber(t)
+	case *big.Int:
+		if t == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(t), true
+	case big.Int:
+		return new(big.Rat).SetInt(&t), true
+	case *big.Rat:
+		if t == nil {
+			return nil, false
+		}
+		return new(big.Rat).Set(t), true
+	case big.Rat:
+		return new(big.Rat).Set(&t), true
+	case *big.Float:
+		if t == nil || t.IsInf() {
+			return nil, false
+		}
+		r, _ := t.Rat(nil)
+		return r, true
+	case big.Float:
+		return Rat(&t)
+	}
+	v := reflect.ValueOf(input)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+	return nil, false
+}
+
+// parseNumber returns the value of n, which is in the syntax of JSON.
+func parseNumber(n json.Number) (*big.Rat, bool) {
+	m := rNumber.FindStringSubmatch(string(n))
+	if m == nil {
+		return nil, false
+	}
+	if m[1] != "" {
+		e, err := strconv.Atoi(m[1])
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return nil, false
+		}
+	}
+	return new(big.Rat).SetString(string(n))
+}
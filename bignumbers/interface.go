@@ -0,0 +1,14 @@
+package bignumbers
+
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates a number of any numeric type.
+type Validator = validators.Validator[interface{}]
+
+var (
+	_ Validator = ConstValidator{}
+	_ Validator = EnumValidator{}
+	_ Validator = MaximumValidator{}
+	_ Validator = MinimumValidator{}
+	_ Validator = MultipleOfValidator{}
+)
@@ -0,0 +1,55 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var MaximumDefinitionNotNumberError = errors.New("the Maximum should be a number")
+
+type MaximumValidator struct {
+	definition MaximumValidatorDefinition
+	maximum    *big.Rat
+}
+
+type MaximumValidatorDefinition struct {
+	Maximum   json.Number `json:"maximum"`
+	Exclusive bool        `json:"exclusive"`
+}
+
+type MaximumValidationError struct {
+	Definition MaximumValidatorDefinition `json:"definition"`
+	Input      interface{}                `json:"input"`
+}
+
+func (err MaximumValidationError) Error() string {
+	if err.Definition.Exclusive {
+		return fmt.Sprintf("the value %v should be less than %s", err.Input, err.Definition.Maximum)
+	}
+	return fmt.Sprintf("the value %v should be less than or equal to %s", err.Input, err.Definition.Maximum)
+}
+
+func NewMaximumValidator(definition MaximumValidatorDefinition) (MaximumValidator, error) {
+	maximum, ok := parseNumber(definition.Maximum)
+	if !ok {
+		return MaximumValidator{}, MaximumDefinitionNotNumberError
+	}
+	return MaximumValidator{definition, maximum}, nil
+}
+
+func (m MaximumValidator) Validate(input interface{}) error {
+	r, ok := Rat(input)
+	if !ok {
+		return &TypeError{input}
+	}
+	c := r.Cmp(m.maximum)
+	if c < 0 || c == 0 && !m.definition.Exclusive {
+		return nil
+	}
+	return &MaximumValidationError{
+		m.definition,
+		input,
+	}
+}
@@ -0,0 +1,119 @@
+package bignumbers_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+func TestNewMaximumValidator(t *testing.T) {
+	type Case struct {
+		Message    string
+		Definition bignumbers.MaximumValidatorDefinition
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "integer",
+			Definition: bignumbers.MaximumValidatorDefinition{Maximum: "9007199254740993"},
+			Error:      nil,
+		},
+		{
+			Message:    "decimal with exponent",
+			Definition: bignumbers.MaximumValidatorDefinition{Maximum: "-1.5e-3"},
+			Error:      nil,
+		},
+		{
+			Message:    "empty",
+			Definition: bignumbers.MaximumValidatorDefinition{},
+			Error:      bignumbers.MaximumDefinitionNotNumberError,
+		},
+		{
+			Message:    "fraction",
+			Definition: bignumbers.MaximumValidatorDefinition{Maximum: "1/3"},
+			Error:      bignumbers.MaximumDefinitionNotNumberError,
+		},
+	}
+	for _, c := range cases {
+		if _, err := bignumbers.NewMaximumValidator(c.Definition); err != c.Error {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestValidateOfMaximumValidator(t *testing.T) {
+	inclusive := bignumbers.MaximumValidatorDefinition{Maximum: "9007199254740993"}
+	exclusive := bignumbers.MaximumValidatorDefinition{Maximum: "9007199254740993", Exclusive: true}
+
+	type Case struct {
+		Message    string
+		Definition bignumbers.MaximumValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "equal json.Number",
+			Definition: inclusive,
+			Input:      json.Number("9007199254740993"),
+			Error:      nil,
+		},
+		{
+			Message:    "greater json.Number which is equal as float64",
+			Definition: inclusive,
+			Input:      json.Number("9007199254740994"),
+			Error: &bignumbers.MaximumValidationError{
+				Definition: inclusive,
+				Input:      json.Number("9007199254740994"),
+			},
+		},
+		{
+			Message:    "less int64",
+			Definition: exclusive,
+			Input:      int64(9007199254740992),
+			Error:      nil,
+		},
+		{
+			Message:    "equal uint64 with exclusive",
+			Definition: exclusive,
+			Input:      uint64(9007199254740993),
+			Error: &bignumbers.MaximumValidationError{
+				Definition: exclusive,
+				Input:      uint64(9007199254740993),
+			},
+		},
+		{
+			Message:    "greater big.Int",
+			Definition: inclusive,
+			Input:      new(big.Int).Lsh(big.NewInt(1), 64),
+			Error: &bignumbers.MaximumValidationError{
+				Definition: inclusive,
+				Input:      new(big.Int).Lsh(big.NewInt(1), 64),
+			},
+		},
+		{
+			Message:    "less big.Rat",
+			Definition: exclusive,
+			Input:      big.NewRat(18014398509481985, 2),
+			Error:      nil,
+		},
+		{
+			Message:    "not a number",
+			Definition: inclusive,
+			Input:      "1",
+			Error:      &bignumbers.TypeError{Input: "1"},
+		},
+	}
+	for _, c := range cases {
+		v, err := bignumbers.NewMaximumValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var MinimumDefinitionNotNumberError = errors.New("the Minimum should be a number")
+
+type MinimumValidator struct {
+	definition MinimumValidatorDefinition
+	minimum    *big.Rat
+}
+
+type MinimumValidatorDefinition struct {
+	Minimum   json.Number `json:"minimum"`
+	Exclusive bool        `json:"exclusive"`
+}
+
+type MinimumValidationError struct {
+	Definition MinimumValidatorDefinition `json:"definition"`
+	Input      interface{}                `json:"input"`
+}
+
+func (err MinimumValidationError) Error() string {
+	if err.Definition.Exclusive {
+		return fmt.Sprintf("the value %v should be greater than %s", err.Input, err.Definition.Minimum)
+	}
+	return fmt.Sprintf("the value %v should be greater than or equal to %s", err.Input, err.Definition.Minimum)
+}
+
+func NewMinimumValidator(definition MinimumValidatorDefinition) (MinimumValidator, error) {
+	minimum, ok := parseNumber(definition.Minimum)
+	if !ok {
+		return MinimumValidator{}, MinimumDefinitionNotNumberError
+	}
+	return MinimumValidator{definition, minimum}, nil
+}
+
+func (m MinimumValidator) Validate(input interface{}) error {
+	r, ok := Rat(input)
+	if !ok {
+		return &TypeError{input}
+	}
+	c := r.Cmp(m.minimum)
+	if c > 0 || c == 0 && !m.definition.Exclusive {
+		return nil
+	}
+	return &MinimumValidationError{
+		m.definition,
+		input,
+	}
+}
@@ -0,0 +1,97 @@
+package bignumbers_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+func TestNewMinimumValidator(t *testing.T) {
+	type Case struct {
+		Message    string
+		Definition bignumbers.MinimumValidatorDefinition
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "decimal",
+			Definition: bignumbers.MinimumValidatorDefinition{Minimum: "0.1"},
+			Error:      nil,
+		},
+		{
+			Message:    "hexadecimal",
+			Definition: bignumbers.MinimumValidatorDefinition{Minimum: "0x10"},
+			Error:      bignumbers.MinimumDefinitionNotNumberError,
+		},
+	}
+	for _, c := range cases {
+		if _, err := bignumbers.NewMinimumValidator(c.Definition); err != c.Error {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestValidateOfMinimumValidator(t *testing.T) {
+	inclusive := bignumbers.MinimumValidatorDefinition{Minimum: "0.1"}
+	exclusive := bignumbers.MinimumValidatorDefinition{Minimum: "0.1", Exclusive: true}
+
+	type Case struct {
+		Message    string
+		Definition bignumbers.MinimumValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "equal json.Number",
+			Definition: inclusive,
+			Input:      json.Number("1e-1"),
+			Error:      nil,
+		},
+		{
+			Message:    "equal json.Number with exclusive",
+			Definition: exclusive,
+			Input:      json.Number("0.10"),
+			Error: &bignumbers.MinimumValidationError{
+				Definition: exclusive,
+				Input:      json.Number("0.10"),
+			},
+		},
+		{
+			Message:    "float64 which is less than 0.1 exactly",
+			Definition: inclusive,
+			Input:      0.09999999999999999,
+			Error: &bignumbers.MinimumValidationError{
+				Definition: inclusive,
+				Input:      0.09999999999999999,
+			},
+		},
+		{
+			Message:    "greater big.Float",
+			Definition: exclusive,
+			Input:      big.NewFloat(0.125),
+			Error:      nil,
+		},
+		{
+			Message:    "less int8",
+			Definition: inclusive,
+			Input:      int8(0),
+			Error: &bignumbers.MinimumValidationError{
+				Definition: inclusive,
+				Input:      int8(0),
+			},
+		},
+	}
+	for _, c := range cases {
+		v, err := bignumbers.NewMinimumValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
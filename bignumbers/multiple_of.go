@@ -0,0 +1,50 @@
+package bignumbers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var MultipleOfDefinitionNotPositiveError = errors.New("the MultipleOf should be a number greater than 0")
+
+type MultipleOfValidator struct {
+	definition MultipleOfValidatorDefinition
+	multipleOf *big.Rat
+}
+
+type MultipleOfValidatorDefinition struct {
+	MultipleOf json.Number `json:"multiple_of"`
+}
+
+type MultipleOfValidationError struct {
+	Definition MultipleOfValidatorDefinition `json:"definition"`
+	Input      interface{}                   `json:"input"`
+}
+
+func (err MultipleOfValidationError) Error() string {
+	return fmt.Sprintf("the value %v should be a multiple of %s", err.Input, err.Definition.MultipleOf)
+}
+
+func NewMultipleOfValidator(definition MultipleOfValidatorDefinition) (MultipleOfValidator, error) {
+	multipleOf, ok := parseNumber(definition.MultipleOf)
+	if !ok || multipleOf.Sign() <= 0 {
+		return MultipleOfValidator{}, MultipleOfDefinitionNotPositiveError
+	}
+	return MultipleOfValidator{definition, multipleOf}, nil
+}
+
+func (m MultipleOfValidator) Validate(input interface{}) error {
+	r, ok := Rat(input)
+	if !ok {
+		return &TypeError{input}
+	}
+	if new(big.Rat).Quo(r, m.multipleOf).IsInt() {
+		return nil
+	}
+	return &MultipleOfValidationError{
+		m.definition,
+		input,
+	}
+}
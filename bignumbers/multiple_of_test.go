@@ -0,0 +1,92 @@
+package bignumbers_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+func TestNewMultipleOfValidator(t *testing.T) {
+	type Case struct {
+		Message    string
+		Definition bignumbers.MultipleOfValidatorDefinition
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "positive",
+			Definition: bignumbers.MultipleOfValidatorDefinition{MultipleOf: "0.01"},
+			Error:      nil,
+		},
+		{
+			Message:    "zero",
+			Definition: bignumbers.MultipleOfValidatorDefinition{MultipleOf: "0"},
+			Error:      bignumbers.MultipleOfDefinitionNotPositiveError,
+		},
+		{
+			Message:    "negative",
+			Definition: bignumbers.MultipleOfValidatorDefinition{MultipleOf: "-2"},
+			Error:      bignumbers.MultipleOfDefinitionNotPositiveError,
+		},
+	}
+	for _, c := range cases {
+		if _, err := bignumbers.NewMultipleOfValidator(c.Definition); err != c.Error {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestValidateOfMultipleOfValidator(t *testing.T) {
+	def := bignumbers.MultipleOfValidatorDefinition{MultipleOf: "0.01"}
+	v, err := bignumbers.NewMultipleOfValidator(def)
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+
+	type Case struct {
+		Message string
+		Input   interface{}
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "multiple json.Number",
+			Input:   json.Number("19.99"),
+			Error:   nil,
+		},
+		{
+			Message: "large multiple json.Number",
+			Input:   json.Number("123456789012345678.91"),
+			Error:   nil,
+		},
+		{
+			Message: "not multiple json.Number",
+			Input:   json.Number("0.075"),
+			Error: &bignumbers.MultipleOfValidationError{
+				Definition: def,
+				Input:      json.Number("0.075"),
+			},
+		},
+		{
+			Message: "multiple int",
+			Input:   3,
+			Error:   nil,
+		},
+		{
+			Message: "not multiple big.Rat",
+			Input:   big.NewRat(1, 3),
+			Error: &bignumbers.MultipleOfValidationError{
+				Definition: def,
+				Input:      big.NewRat(1, 3),
+			},
+		},
+	}
+	for _, c := range cases {
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
@@ -1,5 +1,12 @@
 package booleans
 
-type Validator interface {
-	Validate(input bool) error
-}
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates a boolean.
+type Validator = validators.Validator[bool]
+
+var (
+	_ Validator = EnumValidator{}
+)
+
+var _ validators.Validator[interface{}] = ValueValidator{}
@@ -0,0 +1,75 @@
+package booleans
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-jstmpl/go-jsvalidator/internal/nullable"
+)
+
+var ValueDefinitionNoValidatorError = errors.New("the Validator should not be nil")
+
+// ValueValidator validates the values which hold a boolean, such as *bool,
+// sql.NullBool, dbr.NullBool and the other driver.Valuer, with Validator.
+type ValueValidator struct {
+	definition ValueValidatorDefinition
+}
+
+type ValueValidatorDefinition struct {
+	Validator Validator `json:"-"`
+	// Nullable reports whether nil, the nil pointers and NULL are valid.
+	// They are NullValidationError otherwise.
+	Nullable bool `json:"nullable"`
+}
+
+type NullValidationError struct {
+	Definition ValueValidatorDefinition `json:"definition"`
+	Input      interface{}              `json:"input"`
+}
+
+func (err NullValidationError) Error() string {
+	return "the value should not be null"
+}
+
+// ValueTypeError for the values which don't hold a boolean
+type ValueTypeError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err ValueTypeError) Error() string {
+	return fmt.Sprintf("%T doesn't hold a boolean", err.Input)
+}
+
+func NewValueValidator(definition ValueValidatorDefinition) (ValueValidator, error) {
+	if definition.Validator == nil {
+		return ValueValidator{}, ValueDefinitionNoValidatorError
+	}
+	return ValueValidator{definition}, nil
+}
+
+func (v ValueValidator) Validate(input interface{}) error {
+	value, null, err := nullable.Unwrap(input)
+	if err != nil {
+		return err
+	}
+	if null {
+		if v.definition.Nullable {
+			return nil
+		}
+		return &NullValidationError{v.definition, input}
+	}
+	b, ok := toBool(value)
+	if !ok {
+		return &ValueTypeError{input}
+	}
+	return v.definition.Validator.Validate(b)
+}
+
+// toBool returns the bool of value.
+func toBool(value interface{}) (bool, bool) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Bool {
+		return v.Bool(), true
+	}
+	return false, false
+}
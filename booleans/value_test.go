@@ -0,0 +1,101 @@
+package booleans_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/booleans"
+	"github.com/gocraft/dbr"
+)
+
+func TestNewValueValidator(t *testing.T) {
+	if _, err := booleans.NewValueValidator(booleans.ValueValidatorDefinition{}); err != booleans.ValueDefinitionNoValidatorError {
+		t.Errorf("expected %v, but actual %v", booleans.ValueDefinitionNoValidatorError, err)
+	}
+}
+
+func TestValidateOfValueValidator(t *testing.T) {
+	enum, err := booleans.NewEnumValidator(booleans.EnumValidatorDefinition{Enum: []bool{true}})
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+	nullable := booleans.ValueValidatorDefinition{Validator: enum, Nullable: true}
+	notNullable := booleans.ValueValidatorDefinition{Validator: enum}
+	yes, no := true, false
+
+	type Case struct {
+		Message    string
+		Definition booleans.ValueValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "valid bool",
+			Definition: notNullable,
+			Input:      true,
+			Error:      nil,
+		},
+		{
+			Message:    "valid pointer",
+			Definition: notNullable,
+			Input:      &yes,
+			Error:      nil,
+		},
+		{
+			Message:    "invalid pointer",
+			Definition: notNullable,
+			Input:      &no,
+			Error: &booleans.EnumValidationError{
+				Definition: booleans.EnumValidatorDefinition{Enum: []bool{true}},
+				Input:      false,
+			},
+		},
+		{
+			Message:    "valid sql.NullBool",
+			Definition: notNullable,
+			Input:      sql.NullBool{Bool: true, Valid: true},
+			Error:      nil,
+		},
+		{
+			Message:    "invalid dbr.NullBool",
+			Definition: notNullable,
+			Input:      dbr.NewNullBool(false),
+			Error: &booleans.EnumValidationError{
+				Definition: booleans.EnumValidatorDefinition{Enum: []bool{true}},
+				Input:      false,
+			},
+		},
+		{
+			Message:    "NULL with nullable",
+			Definition: nullable,
+			Input:      dbr.NewNullBool(nil),
+			Error:      nil,
+		},
+		{
+			Message:    "NULL without nullable",
+			Definition: notNullable,
+			Input:      sql.NullBool{},
+			Error: &booleans.NullValidationError{
+				Definition: notNullable,
+				Input:      sql.NullBool{},
+			},
+		},
+		{
+			Message:    "wrong type",
+			Definition: notNullable,
+			Input:      sql.NullString{String: "true", Valid: true},
+			Error:      &booleans.ValueTypeError{Input: sql.NullString{String: "true", Valid: true}},
+		},
+	}
+	for _, c := range cases {
+		v, err := booleans.NewValueValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
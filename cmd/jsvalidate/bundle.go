@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+// mapFlag is the -map flags of bundle, which map the URI prefixes to the
+// local directories.
+type mapFlag schema.DirLoader
+
+func (m mapFlag) String() string {
+	var pairs []string
+	for prefix, dir := range m {
+		pairs = append(pairs, prefix+"="+dir)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m mapFlag) Set(value string) error {
+	i := strings.LastIndex(value, "=")
+	if i <= 0 {
+		return fmt.Errorf("%q should be prefix=dir", value)
+	}
+	m[value[:i]] = value[i+1:]
+	return nil
+}
+
+func runBundle(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jsvalidate bundle", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dirs := mapFlag{}
+	flags.Var(dirs, "map", "map the URI prefix to the local directory, such as https://schemas.example.com/=./schemas (repeatable)")
+	var (
+		out    = flags.String("o", "", "the file to write the bundle (default: stdout)")
+		draft  = flags.String("draft", "", "the draft of the schemas without $schema, such as 07 or 2020-12")
+		remote = flags.Bool("http", false, "load the http and https references which are not mapped")
+	)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: jsvalidate bundle [flags] schema.json")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	fail := func(err error) int {
+		fmt.Fprintf(stderr, "jsvalidate: %s\n", err)
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+	compiler := schema.NewCompiler()
+	if *draft != "" {
+		d, err := schema.ParseDraft(*draft)
+		if err != nil {
+			return fail(err)
+		}
+		compiler.Draft = d
+	}
+	loaders := schema.Loaders{schema.DirLoader(dirs), schema.FileLoader{}}
+	if *remote {
+		loaders = append(loaders, schema.HTTPLoader{})
+	}
+	compiler.Loader = loaders
+
+	file := flags.Arg(0)
+	bundle, err := compiler.BundleFile(file)
+	if err != nil {
+		return fail(fmt.Errorf("%s: %s", file, err))
+	}
+	b, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fail(err)
+	}
+	b = append(b, '\n')
+	if *out == "" {
+		stdout.Write(b)
+		return exitValid
+	}
+	if err := ioutil.WriteFile(*out, b, 0644); err != nil {
+		return fail(err)
+	}
+	return exitValid
+}
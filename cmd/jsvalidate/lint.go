@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+// lintResult is the result of a schema in the json output of lint.
+type lintResult struct {
+	File     string        `json:"file"`
+	Errors   []lintMessage `json:"errors,omitempty"`
+	Warnings []lintMessage `json:"warnings,omitempty"`
+}
+
+type lintMessage struct {
+	Location string `json:"location"`
+	Keyword  string `json:"keyword"`
+	Message  string `json:"message"`
+}
+
+func runLint(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("jsvalidate lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		output = flags.String("output", "human", "the output format: human or json")
+		draft  = flags.String("draft", "", "the draft of the schemas without $schema, such as 07 or 2020-12")
+		strict = flags.Bool("strict", false, "exit with 1 when there are warnings")
+	)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: jsvalidate lint [flags] schema.json ...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	fail := func(err error) int {
+		fmt.Fprintf(stderr, "jsvalidate: %s\n", err)
+		return exitError
+	}
+	if *output != "human" && *output != "json" {
+		return fail(fmt.Errorf("unknown output format %q", *output))
+	}
+	compiler := schema.NewCompiler()
+	if *draft != "" {
+		d, err := schema.ParseDraft(*draft)
+		if err != nil {
+			return fail(err)
+		}
+		compiler.Draft = d
+	}
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	status := exitValid
+	results := []lintResult{}
+	for _, file := range files {
+		data, err := readFile(file, stdin)
+		if err != nil {
+			fail(err)
+			status = exitError
+			continue
+		}
+		doc, err := decode(data, format(file, ""))
+		if err != nil {
+			fail(fmt.Errorf("%s: %s", file, err))
+			status = exitError
+			continue
+		}
+		errs, warnings := compiler.Lint(doc)
+		r := lintResult{File: file}
+		for _, e := range errs {
+			r.Errors = append(r.Errors, lintMessage{e.Location, e.Keyword, e.Err.Error()})
+		}
+		for _, w := range warnings {
+			r.Warnings = append(r.Warnings, lintMessage{w.Location, w.Keyword, w.Message})
+		}
+		if status == exitValid && (len(errs) > 0 || (*strict && len(warnings) > 0)) {
+			status = exitInvalid
+		}
+		results = append(results, r)
+	}
+
+	if *output == "json" {
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fail(err)
+		}
+		fmt.Fprintf(stdout, "%s\n", b)
+		return status
+	}
+	for _, r := range results {
+		for _, e := range r.Errors {
+			fmt.Fprintf(stdout, "%s: %s: error: %s\n", r.File, e.Location, e.Message)
+		}
+		for _, w := range r.Warnings {
+			fmt.Fprintf(stdout, "%s: %s: warning: %s\n", r.File, w.Location, w.Message)
+		}
+	}
+	return status
+}
@@ -0,0 +1,281 @@
+// Command jsvalidate validates JSON and YAML instances against a JSON Schema.
+//
+//	jsvalidate -schema schema.json [-input json|yaml|ndjson] [-output human|json] [-lang en|ja] [-workers n] [file ...]
+//	jsvalidate lint [-output human|json] [-strict] schema.json ...
+//	jsvalidate bundle [-map prefix=dir ...] [-http] [-o bundle.json] schema.json
+//
+// The instances are read from the files, or from stdin when no file or "-"
+// is given. The format of each file is decided by its extension (.yaml and
+// .yml are YAML, .ndjson and .jsonl are NDJSON, and the others are JSON)
+// unless -input is given. Every line of NDJSON is validated as an instance,
+// by -workers goroutines concurrently. The messages of the errors are in the
+// language of -lang, and the errors of YAML have the lines and the columns
+// of the values.
+//
+// The exit status is 0 when all instances are valid, 1 when some instances
+// are invalid, and 2 when the schema or the instances can't be read.
+//
+// The lint subcommand reports all definition errors and the warnings of the
+// schemas, and exits with 1 when there are errors, or warnings with -strict.
+//
+// The bundle subcommand writes the schema with the documents which its $refs
+// reference in $defs, so that it's self-contained. The relative references
+// are loaded from the files, and the URIs under the prefixes of -map from
+// the local directories.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const (
+	exitValid   = 0
+	exitInvalid = 1
+	exitError   = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// result is the result of an instance in the json output.
+type result struct {
+	File   string        `json:"file"`
+	Line   int           `json:"line,omitempty"`
+	Valid  bool          `json:"valid"`
+	Errors []resultError `json:"errors,omitempty"`
+}
+
+type resultError struct {
+	InstancePath string `json:"instance_path"`
+	SchemaPath   string `json:"schema_path"`
+	Keyword      string `json:"keyword"`
+	Message      string `json:"message"`
+	// Line and Column are the position of the value in YAML.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "lint" {
+		return runLint(args[1:], stdin, stdout, stderr)
+	}
+	if len(args) > 0 && args[0] == "bundle" {
+		return runBundle(args[1:], stdout, stderr)
+	}
+	flags := flag.NewFlagSet("jsvalidate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		schemaFile = flags.String("schema", "", "the JSON Schema file (JSON or YAML)")
+		input      = flags.String("input", "", "the format of the instances: json, yaml or ndjson (default: by the extension)")
+		output     = flags.String("output", "human", "the output format: human or json")
+		draft      = flags.String("draft", "", "the draft of the schema without $schema, such as 07 or 2020-12")
+		lang       = flags.String("lang", "en", "the language of the messages, such as en or ja")
+		workers    = flags.Int("workers", 0, "the number of the goroutines which validate the lines of NDJSON (default: GOMAXPROCS)")
+	)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: jsvalidate -schema schema.json [flags] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	fail := func(err error) int {
+		fmt.Fprintf(stderr, "jsvalidate: %s\n", err)
+		return exitError
+	}
+	if *schemaFile == "" {
+		flags.Usage()
+		return exitError
+	}
+	switch *input {
+	case "", "json", "yaml", "ndjson":
+	default:
+		return fail(fmt.Errorf("unknown input format %q", *input))
+	}
+	if *output != "human" && *output != "json" {
+		return fail(fmt.Errorf("unknown output format %q", *output))
+	}
+
+	compiler := schema.NewCompiler()
+	if *draft != "" {
+		d, err := schema.ParseDraft(*draft)
+		if err != nil {
+			return fail(err)
+		}
+		compiler.Draft = d
+	}
+	s, err := compileSchema(compiler, *schemaFile, stdin)
+	if err != nil {
+		return fail(fmt.Errorf("%s: %s", *schemaFile, err))
+	}
+
+	catalog := messages.Lookup(*lang)
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	status := exitValid
+	var results []result
+	for _, file := range files {
+		data, err := readFile(file, stdin)
+		if err != nil {
+			fail(err)
+			status = exitError
+			continue
+		}
+		rs, err := validate(s, catalog, file, data, format(file, *input), *workers)
+		results = append(results, rs...)
+		if err != nil {
+			fail(err)
+			status = exitError
+		}
+	}
+
+	for _, r := range results {
+		if !r.Valid && status == exitValid {
+			status = exitInvalid
+		}
+	}
+	if *output == "json" {
+		if results == nil {
+			results = []result{}
+		}
+		b, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fail(err)
+		}
+		fmt.Fprintf(stdout, "%s\n", b)
+		return status
+	}
+	for _, r := range results {
+		for _, e := range r.Errors {
+			name := r.File
+			switch {
+			case e.Line > 0:
+				name = fmt.Sprintf("%s:%d:%d", name, e.Line, e.Column)
+			case r.Line > 0:
+				name = fmt.Sprintf("%s:%d", name, r.Line)
+			}
+			path := e.InstancePath
+			if path == "" {
+				path = "(root)"
+			}
+			fmt.Fprintf(stdout, "%s: %s: %s\n", name, path, e.Message)
+		}
+	}
+	return status
+}
+
+// validate validates the instances in data, and returns their results with
+// the messages in the catalog c. The lines of NDJSON are validated by the workers concurrently.
+// The results of the instances before a decoding error are returned with it.
+func validate(s *schema.Schema, c *messages.Catalog, file string, data []byte, format string, workers int) ([]result, error) {
+	if format == "yaml" {
+		d, err := schema.DecodeYAML(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		return []result{newResult(c, file, 0, d.Locate(s.Validate(d.Value)))}, nil
+	}
+	if format != "ndjson" {
+		v, err := decode(data, format)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+		return []result{newResult(c, file, 0, s.Validate(v))}, nil
+	}
+
+	var results []result
+	v := &schema.LinesValidator{Schema: s, Workers: workers}
+	err := v.Validate(bytes.NewReader(data), func(r schema.LineResult) error {
+		if _, ok := r.Err.(schema.ValidationErrors); r.Err != nil && !ok {
+			return fmt.Errorf("%s:%d: %s", file, r.Line, r.Err)
+		}
+		results = append(results, newResult(c, file, r.Line, r.Err))
+		return nil
+	})
+	return results, err
+}
+
+func newResult(c *messages.Catalog, file string, line int, err error) result {
+	r := result{File: file, Line: line, Valid: err == nil}
+	if errs, ok := err.(schema.ValidationErrors); ok {
+		for _, e := range errs {
+			r.Errors = append(r.Errors, resultError{e.InstancePath, e.SchemaPath, e.Keyword, e.Message(c), e.Line, e.Column})
+		}
+	}
+	return r
+}
+
+// compileSchema compiles the schema of file. The relative references of the
+// file are resolved against its path like bundle, but the schema from stdin
+// has no path.
+func compileSchema(compiler *schema.Compiler, file string, stdin io.Reader) (*schema.Schema, error) {
+	if file != "-" {
+		return compiler.LoadFile(file)
+	}
+	doc, err := readFile(file, stdin)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := decode(doc, format(file, ""))
+	if err != nil {
+		return nil, err
+	}
+	return compiler.Compile(raw)
+}
+
+func readFile(file string, stdin io.Reader) ([]byte, error) {
+	if file == "-" {
+		return ioutil.ReadAll(stdin)
+	}
+	return ioutil.ReadFile(file)
+}
+
+// format returns the format of file, which is input unless it is empty.
+func format(file, input string) string {
+	if input != "" {
+		return input
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	}
+	return "json"
+}
+
+// decode decodes a JSON or YAML document into the values which schema handles.
+func decode(data []byte, format string) (interface{}, error) {
+	if format == "yaml" {
+		d, err := schema.DecodeYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		return d.Value, nil
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after the top-level value")
+	}
+	return v, nil
+}
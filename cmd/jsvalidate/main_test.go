@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	bundled, err := ioutil.ReadFile("testdata/bundled.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing, err := filepath.Abs("testdata/missing.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type Case struct {
+		Message string
+		Args    []string
+		Stdin   string
+		Status  int
+		Stdout  string
+		Stderr  string
+	}
+	cases := []Case{
+		{
+			Message: "valid JSON",
+			Args:    []string{"-schema", "testdata/schema.json", "testdata/valid.json"},
+			Status:  exitValid,
+		},
+		{
+			Message: "valid YAML with YAML schema",
+			Args:    []string{"-schema", "testdata/schema.yaml", "testdata/valid.yaml", "testdata/valid.json"},
+			Status:  exitValid,
+		},
+		{
+			Message: "invalid JSON",
+			Args:    []string{"-schema", "testdata/schema.json", "testdata/valid.json", "testdata/invalid.json"},
+			Status:  exitInvalid,
+			Stdout: "testdata/invalid.json: /age: the value -1 should be greater than or equal to 0\n" +
+				"testdata/invalid.json: /name: should be less than, or equal to, 5 characters but actual value has 9 characters\n",
+		},
+		{
+			Message: "Japanese messages",
+			Args:    []string{"-schema", "testdata/schema.json", "-lang", "ja", "testdata/invalid.json", "testdata/invalid.yaml"},
+			Status:  exitInvalid,
+			Stdout: "testdata/invalid.json: /age: 値 -1 は 0 以上である必要があります\n" +
+				"testdata/invalid.json: /name: 5 文字以下である必要がありますが、9 文字あります\n" +
+				"testdata/invalid.yaml:1:1: (root): プロパティ 'name' は必須です\n",
+		},
+		{
+			Message: "invalid YAML",
+			Args:    []string{"-schema", "testdata/schema.json", "testdata/invalid.yaml"},
+			Status:  exitInvalid,
+			Stdout:  "testdata/invalid.yaml:1:1: (root): the property 'name' is required\n",
+		},
+		{
+			Message: "NDJSON",
+			Args:    []string{"-schema", "testdata/schema.json", "testdata/users.ndjson"},
+			Status:  exitInvalid,
+			Stdout: "testdata/users.ndjson:3: /name: should be less than, or equal to, 5 characters but actual value has 9 characters\n" +
+				"testdata/users.ndjson:4: (root): the property 'name' is required\n",
+		},
+		{
+			Message: "stdin",
+			Args:    []string{"-schema", "testdata/schema.json", "-input", "yaml"},
+			Stdin:   "name: Alice\n",
+			Status:  exitValid,
+		},
+		{
+			Message: "json output",
+			Args:    []string{"-schema", "testdata/schema.json", "-output", "json", "-"},
+			Stdin:   `{"age": 1}`,
+			Status:  exitInvalid,
+			Stdout: `[
+  {
+    "file": "-",
+    "valid": false,
+    "errors": [
+      {
+        "instance_path": "",
+        "schema_path": "#/required",
+        "keyword": "required",
+        "message": "the property 'name' is required"
+      }
+    ]
+  }
+]
+`,
+		},
+		{
+			Message: "broken instance",
+			Args:    []string{"-schema", "testdata/schema.json", "testdata/broken.json", "testdata/invalid.yaml"},
+			Status:  exitError,
+			Stdout:  "testdata/invalid.yaml:1:1: (root): the property 'name' is required\n",
+			Stderr:  "jsvalidate: testdata/broken.json: unexpected EOF\n",
+		},
+		{
+			Message: "missing schema",
+			Args:    []string{"-schema", "testdata/missing.json"},
+			Status:  exitError,
+			Stderr: "jsvalidate: testdata/missing.json: can't load 'file://" + filepath.ToSlash(missing) + "': " +
+				"open " + missing + ": no such file or directory\n",
+		},
+		{
+			Message: "relative reference",
+			Args:    []string{"-schema", "testdata/ref.json", "-input", "json"},
+			Stdin:   `{"users": [{"name": "Alice"}, {"age": 1}]}`,
+			Status:  exitInvalid,
+			Stdout:  "-: /users/1: the property 'name' is required\n",
+		},
+		{
+			Message: "lint",
+			Args:    []string{"lint", "testdata/lint.json", "testdata/schema.yaml"},
+			Status:  exitInvalid,
+			Stdout: "testdata/lint.json: #/properties/age/maximum: error: the value of the keyword has an invalid type\n" +
+				"testdata/lint.json: #/properties/name/minLength: warning: minLength 10 is greater than maxLength 5, so no string is valid\n",
+		},
+		{
+			Message: "lint without errors",
+			Args:    []string{"lint", "-output", "json", "testdata/schema.json"},
+			Status:  exitValid,
+			Stdout:  "[\n  {\n    \"file\": \"testdata/schema.json\"\n  }\n]\n",
+		},
+		{
+			Message: "YAML positions",
+			Args:    []string{"-schema", "testdata/schema.yaml", "-input", "yaml"},
+			Stdin:   "name: Johnny\nage: -1\n",
+			Status:  exitInvalid,
+			Stdout: "-:2:6: /age: the value -1 should be greater than or equal to 0\n" +
+				"-:1:7: /name: should be less than, or equal to, 5 characters but actual value has 6 characters\n",
+		},
+		{
+			Message: "YAML with a non-string key",
+			Args:    []string{"-schema", "testdata/schema.yaml", "-input", "yaml"},
+			Stdin:   "name: Bob\n1: one\n",
+			Status:  exitError,
+			Stderr:  "jsvalidate: -: yaml: line 2, column 1: the key of the mapping should be a string\n",
+		},
+		{
+			Message: "bundle",
+			Args:    []string{"bundle", "-map", "https://schemas.example.com/=testdata", "testdata/bundle.json"},
+			Status:  exitValid,
+			Stdout:  string(bundled),
+		},
+		{
+			Message: "bundle without the map",
+			Args:    []string{"bundle", "testdata/bundle.json"},
+			Status:  exitError,
+			Stderr: "jsvalidate: testdata/bundle.json: invalid schema at '#/properties/owner/$ref': " +
+				"can't load 'https://schemas.example.com/schema.json': the URI is not supported by the loader\n",
+		},
+		{
+			Message: "unknown output",
+			Args:    []string{"-schema", "testdata/schema.json", "-output", "xml"},
+			Status:  exitError,
+			Stderr:  "jsvalidate: unknown output format \"xml\"\n",
+		},
+	}
+
+	for _, c := range cases {
+		var stdout, stderr bytes.Buffer
+		status := run(c.Args, strings.NewReader(c.Stdin), &stdout, &stderr)
+		if status != c.Status {
+			t.Errorf("%s: expected status %d, but actual %d: %s", c.Message, c.Status, status, stderr.String())
+		}
+		if stdout.String() != c.Stdout {
+			t.Errorf("%s: expected stdout %q, but actual %q", c.Message, c.Stdout, stdout.String())
+		}
+		if stderr.String() != c.Stderr {
+			t.Errorf("%s: expected stderr %q, but actual %q", c.Message, c.Stderr, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Command jsvalidator-gen generates Go types and their Validate methods from JSON Schema.
+//
+// It is designed for go generate:
+//
+//	//go:generate jsvalidator-gen -schema article.json -type Article -o article_gen.go
+//
+// The package of the generated file is the package of the file which has the
+// directive, which go generate passes in $GOPACKAGE, unless -package is given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/go-jstmpl/go-jsvalidator/gen"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func main() {
+	var (
+		schemaFile = flag.String("schema", "", "the JSON Schema file")
+		pkg        = flag.String("package", os.Getenv("GOPACKAGE"), "the package name of the generated file")
+		typ        = flag.String("type", "", "the name of the type of the root schema (default: the title of the schema)")
+		out        = flag.String("o", "", "the output file (default: stdout)")
+	)
+	flag.Parse()
+
+	if err := run(*schemaFile, *pkg, *typ, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "jsvalidator-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaFile, pkg, typ, out string) error {
+	if schemaFile == "" {
+		return fmt.Errorf("-schema is required")
+	}
+	if pkg == "" {
+		return fmt.Errorf("-package is required outside of go generate")
+	}
+	data, err := ioutil.ReadFile(schemaFile)
+	if err != nil {
+		return err
+	}
+	s, err := schema.Load(data)
+	if err != nil {
+		return err
+	}
+	src, err := gen.NewGenerator(pkg, typ).Generate(s)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
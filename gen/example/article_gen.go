@@ -0,0 +1,268 @@
+// Code generated by jsvalidator-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+var (
+	articleRequired                     = []string{"id", "title", "status", "author"}
+	articleIDMinimum, _                 = integers.NewMinimumValidator(integers.MinimumValidatorDefinition{Minimum: 1, Exclusive: false})
+	articleMetadataValuesMaxLength, _   = strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: 20})
+	articlePublishedAtFormat, _         = strings.NewFormatValidator(strings.FormatValidatorDefinition{Format: "date-time"})
+	articleRatingExclusiveMaximum, _    = numbers.NewMaximumValidator(numbers.MaximumValidatorDefinition{Maximum: 5, Exclusive: true})
+	articleRatingMinimum, _             = numbers.NewMinimumValidator(numbers.MinimumValidatorDefinition{Minimum: 0, Exclusive: false})
+	articleRatingMultipleOf, _          = bignumbers.NewMultipleOfValidator(bignumbers.MultipleOfValidatorDefinition{MultipleOf: "0.01"})
+	articleSlugPattern, _               = strings.NewPatternValidator(strings.PatternValidatorDefinition{Pattern: "^[a-z0-9-]+$"})
+	articleStatusEnum, _                = strings.NewEnumValidator(strings.EnumValidatorDefinition{Enum: []string{"draft", "published"}})
+	articleTagsItemsMaxLength, _        = strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: 10})
+	articleTitleMaxLength, _            = strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: 100})
+	articleTitleMinLength, _            = strings.NewMinLengthValidator(strings.MinLengthValidatorDefinition{MinLength: 1})
+	userRequired                        = []string{"name", "email"}
+	userAgeMaximum, _                   = integers.NewMaximumValidator(integers.MaximumValidatorDefinition{Maximum: 150, Exclusive: false})
+	userAgeMinimum, _                   = integers.NewMinimumValidator(integers.MinimumValidatorDefinition{Minimum: 0, Exclusive: false})
+	userEmailFormat, _                  = strings.NewFormatValidator(strings.FormatValidatorDefinition{Format: "email"})
+	userNameMinLength, _                = strings.NewMinLengthValidator(strings.MinLengthValidatorDefinition{MinLength: 1})
+	articleCommentsItemRequired         = []string{"body"}
+	articleCommentsItemBodyMinLength, _ = strings.NewMinLengthValidator(strings.MinLengthValidatorDefinition{MinLength: 1})
+)
+
+// Article is generated from the schema at '#'.
+//
+// An article of the blog.
+type Article struct {
+	Author      User                  `json:"author"`
+	Comments    []ArticleCommentsItem `json:"comments,omitempty"`
+	Editor      *User                 `json:"editor,omitempty"`
+	ID          int                   `json:"id"`
+	Metadata    map[string]string     `json:"metadata,omitempty"`
+	PublishedAt *string               `json:"published_at,omitempty"`
+	Rating      *float64              `json:"rating,omitempty"`
+	Slug        *string               `json:"slug,omitempty"`
+	Status      string                `json:"status"`
+	Tags        []string              `json:"tags,omitempty"`
+	Title       string                `json:"title"`
+	// missing is the required properties which the decoded JSON doesn't have.
+	missing []string
+}
+
+// UnmarshalJSON decodes data into v, and records the required properties which data doesn't have.
+func (v *Article) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	type plain Article
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.missing = nil
+	for _, property := range articleRequired {
+		if _, ok := properties[property]; !ok {
+			v.missing = append(v.missing, property)
+		}
+	}
+	return nil
+}
+
+// Validate returns the errors of v against the schema of Article.
+func (v *Article) Validate() error {
+	if errs := v.validate(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *Article) validate() schema.ValidationErrors {
+	var errs schema.ValidationErrors
+	for _, property := range v.missing {
+		errs = append(errs, &schema.ValidationError{InstancePath: "", SchemaPath: "#/required", Keyword: "required", Err: &schema.RequiredValidationError{Required: articleRequired, Property: property}})
+	}
+	if e1 := v.Author.validate(); len(e1) > 0 {
+		errs = append(errs, e1.Prefix("/author")...)
+	}
+	for i1 := range v.Comments {
+		if e2 := v.Comments[i1].validate(); len(e2) > 0 {
+			errs = append(errs, e2.Prefix("/comments/"+strconv.Itoa(i1))...)
+		}
+	}
+	if v.Editor != nil {
+		if e1 := v.Editor.validate(); len(e1) > 0 {
+			errs = append(errs, e1.Prefix("/editor")...)
+		}
+	}
+	if err := articleIDMinimum.Validate(v.ID); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/id", SchemaPath: "#/properties/id/minimum", Keyword: "minimum", Err: err})
+	}
+	for k1, v1 := range v.Metadata {
+		if err := articleMetadataValuesMaxLength.Validate(v1); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/metadata/" + schema.EscapePointer(k1), SchemaPath: "#/properties/metadata/additionalProperties/maxLength", Keyword: "maxLength", Err: err})
+		}
+	}
+	if v.PublishedAt != nil {
+		if err := articlePublishedAtFormat.Validate(*v.PublishedAt); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/published_at", SchemaPath: "#/properties/published_at/format", Keyword: "format", Err: err})
+		}
+	}
+	if v.Rating != nil {
+		if err := articleRatingExclusiveMaximum.Validate(*v.Rating); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/rating", SchemaPath: "#/properties/rating/exclusiveMaximum", Keyword: "exclusiveMaximum", Err: err})
+		}
+		if err := articleRatingMinimum.Validate(*v.Rating); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/rating", SchemaPath: "#/properties/rating/minimum", Keyword: "minimum", Err: err})
+		}
+		if err := articleRatingMultipleOf.Validate(json.Number(strconv.FormatFloat(*v.Rating, 'g', -1, 64))); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/rating", SchemaPath: "#/properties/rating/multipleOf", Keyword: "multipleOf", Err: &schema.MultipleOfValidationError{MultipleOf: 0.01, Input: *v.Rating}})
+		}
+	}
+	if v.Slug != nil {
+		if err := articleSlugPattern.Validate(*v.Slug); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/slug", SchemaPath: "#/properties/slug/pattern", Keyword: "pattern", Err: err})
+		}
+	}
+	if err := articleStatusEnum.Validate(v.Status); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/status", SchemaPath: "#/properties/status/enum", Keyword: "enum", Err: err})
+	}
+	if len(v.Tags) > 3 {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/tags", SchemaPath: "#/properties/tags/maxItems", Keyword: "maxItems", Err: &arrays.MaxItemsValidationError{Definition: arrays.MaxItemsValidatorDefinition{MaxItems: 3}, Input: v.Tags}})
+	}
+	for i1 := range v.Tags {
+		if err := articleTagsItemsMaxLength.Validate(v.Tags[i1]); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/tags/" + strconv.Itoa(i1), SchemaPath: "#/properties/tags/items/maxLength", Keyword: "maxLength", Err: err})
+		}
+	}
+	if err := articleTitleMaxLength.Validate(v.Title); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/title", SchemaPath: "#/properties/title/maxLength", Keyword: "maxLength", Err: err})
+	}
+	if err := articleTitleMinLength.Validate(v.Title); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/title", SchemaPath: "#/properties/title/minLength", Keyword: "minLength", Err: err})
+	}
+	return errs
+}
+
+// User is generated from the schema at '#/$defs/user'.
+type User struct {
+	Age   *int   `json:"age,omitempty"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	// missing is the required properties which the decoded JSON doesn't have.
+	missing []string
+}
+
+// UnmarshalJSON decodes data into v, and records the required properties which data doesn't have.
+func (v *User) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	type plain User
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.missing = nil
+	for _, property := range userRequired {
+		if _, ok := properties[property]; !ok {
+			v.missing = append(v.missing, property)
+		}
+	}
+	return nil
+}
+
+// Validate returns the errors of v against the schema of User.
+func (v *User) Validate() error {
+	if errs := v.validate(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *User) validate() schema.ValidationErrors {
+	var errs schema.ValidationErrors
+	for _, property := range v.missing {
+		errs = append(errs, &schema.ValidationError{InstancePath: "", SchemaPath: "#/$defs/user/required", Keyword: "required", Err: &schema.RequiredValidationError{Required: userRequired, Property: property}})
+	}
+	if v.Age != nil {
+		if err := userAgeMaximum.Validate(*v.Age); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/age", SchemaPath: "#/$defs/user/properties/age/maximum", Keyword: "maximum", Err: err})
+		}
+		if err := userAgeMinimum.Validate(*v.Age); err != nil {
+			errs = append(errs, &schema.ValidationError{InstancePath: "/age", SchemaPath: "#/$defs/user/properties/age/minimum", Keyword: "minimum", Err: err})
+		}
+	}
+	if err := userEmailFormat.Validate(v.Email); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/email", SchemaPath: "#/$defs/user/properties/email/format", Keyword: "format", Err: err})
+	}
+	if err := userNameMinLength.Validate(v.Name); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/name", SchemaPath: "#/$defs/user/properties/name/minLength", Keyword: "minLength", Err: err})
+	}
+	return errs
+}
+
+// ArticleCommentsItem is generated from the schema at '#/properties/comments/items'.
+type ArticleCommentsItem struct {
+	Author *User  `json:"author,omitempty"`
+	Body   string `json:"body"`
+	// missing is the required properties which the decoded JSON doesn't have.
+	missing []string
+}
+
+// UnmarshalJSON decodes data into v, and records the required properties which data doesn't have.
+func (v *ArticleCommentsItem) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	type plain ArticleCommentsItem
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.missing = nil
+	for _, property := range articleCommentsItemRequired {
+		if _, ok := properties[property]; !ok {
+			v.missing = append(v.missing, property)
+		}
+	}
+	return nil
+}
+
+// Validate returns the errors of v against the schema of ArticleCommentsItem.
+func (v *ArticleCommentsItem) Validate() error {
+	if errs := v.validate(); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (v *ArticleCommentsItem) validate() schema.ValidationErrors {
+	var errs schema.ValidationErrors
+	for _, property := range v.missing {
+		errs = append(errs, &schema.ValidationError{InstancePath: "", SchemaPath: "#/properties/comments/items/required", Keyword: "required", Err: &schema.RequiredValidationError{Required: articleCommentsItemRequired, Property: property}})
+	}
+	if v.Author != nil {
+		if e1 := v.Author.validate(); len(e1) > 0 {
+			errs = append(errs, e1.Prefix("/author")...)
+		}
+	}
+	if err := articleCommentsItemBodyMinLength.Validate(v.Body); err != nil {
+		errs = append(errs, &schema.ValidationError{InstancePath: "/body", SchemaPath: "#/properties/comments/items/properties/body/minLength", Keyword: "minLength", Err: err})
+	}
+	return errs
+}
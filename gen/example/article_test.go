@@ -0,0 +1,150 @@
+package example_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/gen/example"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+type Case struct {
+	Message  string
+	Instance string
+	Errors   []string
+	// SchemaErrors are the errors of Validate of Schema when they differ
+	// from the errors of the generated code.
+	SchemaErrors []string
+}
+
+func TestArticleValidate(t *testing.T) {
+	data, err := ioutil.ReadFile("article.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Load(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []Case{
+		{
+			Message:  "valid article",
+			Instance: `{"id": 1, "title": "Hello", "status": "draft", "author": {"name": "Alice", "email": "alice@example.com"}}`,
+			Errors:   nil,
+		},
+		{
+			Message: "valid article with optional properties",
+			Instance: `{"id": 1, "title": "Hello", "slug": "hello-world", "status": "published", "rating": 4.99,
+				"tags": ["go", "json"], "author": {"name": "Alice", "email": "alice@example.com", "age": 20},
+				"editor": null, "published_at": "2017-01-01T00:00:00Z", "metadata": {"lang": "en"},
+				"comments": [{"body": "Nice", "author": {"name": "Bob", "email": "bob@example.com"}}]}`,
+			Errors: nil,
+		},
+		{
+			Message:  "invalid scalars",
+			Instance: `{"id": 0, "title": "", "slug": "Hello", "status": "deleted", "rating": 5, "author": {"name": "Alice", "email": "alice@example.com"}}`,
+			Errors: []string{
+				"/id minimum",
+				"/rating exclusiveMaximum",
+				"/slug pattern",
+				"/status enum",
+				"/title minLength",
+			},
+		},
+		{
+			Message: "invalid nested values",
+			Instance: `{"id": 1, "title": "Hello", "status": "draft", "rating": 1.234,
+				"tags": ["a", "b", "c", "too long tag"], "author": {"name": "", "email": "alice", "age": 200},
+				"editor": {"name": "Bob", "email": "bob@example.com"}, "published_at": "yesterday",
+				"metadata": {"a/b": "a value which is too long"}, "comments": [{"body": ""}]}`,
+			Errors: []string{
+				"/author/age maximum",
+				"/author/email format",
+				"/author/name minLength",
+				"/comments/0/body minLength",
+				"/metadata/a~1b maxLength",
+				"/published_at format",
+				"/rating multipleOf",
+				"/tags maxItems",
+				"/tags/3 maxLength",
+			},
+		},
+		{
+			Message:  "missing required properties",
+			Instance: `{"id": 1, "title": "Hello", "author": {"name": "Alice", "email": "alice@example.com"}, "comments": [{"author": {"name": "Bob"}}]}`,
+			Errors: []string{
+				" required",
+				"/comments/0 required",
+				"/comments/0/author required",
+				"/comments/0/author/email format",
+				"/comments/0/body minLength",
+				"/status enum",
+			},
+			SchemaErrors: []string{" required", "/comments/0 required", "/comments/0/author required"},
+		},
+		{
+			Message:      "invalid nullable reference",
+			Instance:     `{"id": 1, "title": "Hello", "status": "draft", "author": {"name": "Alice", "email": "alice@example.com"}, "editor": {"name": "Bob", "email": "bob"}}`,
+			Errors:       []string{"/editor/email format"},
+			SchemaErrors: []string{"/editor anyOf"},
+		},
+	}
+
+	for _, c := range cases {
+		var a example.Article
+		if err := json.Unmarshal([]byte(c.Instance), &a); err != nil {
+			t.Fatalf("%s: %s", c.Message, err)
+		}
+		actual := errorStrings(a.Validate())
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+
+		var instance interface{}
+		if err := json.Unmarshal([]byte(c.Instance), &instance); err != nil {
+			t.Fatalf("%s: %s", c.Message, err)
+		}
+		want := c.Errors
+		if c.SchemaErrors != nil {
+			want = c.SchemaErrors
+		}
+		if fromSchema := errorStrings(s.Validate(instance)); !reflect.DeepEqual(fromSchema, want) {
+			t.Errorf("%s: expected %v from the schema, but actual %v", c.Message, want, fromSchema)
+		}
+	}
+}
+
+func TestArticleValidateAllocations(t *testing.T) {
+	age := 20
+	a := example.Article{
+		ID:     1,
+		Title:  "Hello",
+		Status: "draft",
+		Tags:   []string{"go", "json"},
+		Author: example.User{Name: "Alice", Email: "alice@example.com", Age: &age},
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := a.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocation, but actual %v", allocs)
+	}
+}
+
+func errorStrings(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var ss []string
+	for _, e := range err.(schema.ValidationErrors) {
+		ss = append(ss, e.InstancePath+" "+e.Keyword)
+	}
+	sort.Strings(ss)
+	return ss
+}
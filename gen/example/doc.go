@@ -0,0 +1,4 @@
+// Package example has the types generated by jsvalidator-gen from article.json.
+package example
+
+//go:generate go run ../../cmd/jsvalidator-gen -schema article.json -type Article -o article_gen.go
@@ -0,0 +1,925 @@
+// Package gen generates Go types and their validation code from JSON Schema.
+//
+// The generated Validate methods call the validators of the strings,
+// integers, numbers and booleans packages, which are constructed once
+// as package variables, and check the lengths of slices directly, so that
+// they don't use reflection and don't allocate for valid values, except
+// for the multipleOf of the numbers, which is compared exactly with the
+// bignumbers package.
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/booleans"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	jsstrings "github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+const importPath = "github.com/go-jstmpl/go-jsvalidator/"
+
+var (
+	TypeNameEmptyError   = errors.New("the name of the root type should not be empty")
+	RootNotStructError   = errors.New("the root schema should be an object with properties")
+	RecursiveSchemaError = errors.New("the schema references itself without an object with properties")
+)
+
+// UnsupportedKeywordError for the keywords which the generated code can't evaluate
+type UnsupportedKeywordError struct {
+	Location string `json:"location"`
+	Keyword  string `json:"keyword"`
+}
+
+func (e UnsupportedKeywordError) Error() string {
+	return fmt.Sprintf("the keyword %s at '%s' is not supported by the generator", e.Keyword, e.Location)
+}
+
+// Generator generates the Go source code of the types of a schema.
+type Generator struct {
+	// Package is the name of the package of the generated file.
+	Package string
+	// Type is the name of the type of the root schema.
+	// The title of the root schema is used when it is empty.
+	Type string
+}
+
+func NewGenerator(pkg, typ string) *Generator {
+	return &Generator{Package: pkg, Type: typ}
+}
+
+// Generate generates the Go source code of s.
+//
+// The root schema and the schemas in $defs (definitions before 2019-09),
+// which are objects with properties, are generated as named struct types,
+// and the nested objects with properties are named after their fields.
+// The properties which aren't required, and the nullable values, are
+// pointers. The required properties are checked by the UnmarshalJSON
+// methods of the struct types, which record the missing ones for Validate,
+// since the values made in Go always have them. The other keywords of the
+// missing properties are evaluated on their zero values. The keywords
+// which can't be evaluated on the Go types, such as anyOf, are reported by
+// UnsupportedKeywordError.
+func (g *Generator) Generate(s *schema.Schema) ([]byte, error) {
+	name := g.Type
+	if name == "" {
+		name = exported(s.Title)
+	}
+	if name == "" {
+		return nil, TypeNameEmptyError
+	}
+
+	p := &generation{
+		names:    map[*schema.Schema]string{},
+		used:     map[string]bool{},
+		imports:  map[string]bool{},
+		visiting: map[*schema.Schema]bool{},
+	}
+	root, err := p.deref(s)
+	if err != nil {
+		return nil, err
+	}
+	if !isStruct(root) {
+		return nil, RootNotStructError
+	}
+	p.define(root, name)
+	for _, key := range sortedKeys(s.Defs) {
+		d, err := p.deref(s.Defs[key])
+		if err != nil {
+			return nil, err
+		}
+		if isStruct(d) && p.names[d] == "" {
+			p.define(d, exported(key))
+		}
+	}
+	for i := 0; i < len(p.structs); i++ {
+		if err := p.structType(p.structs[i]); err != nil {
+			return nil, err
+		}
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.source(g.Package)
+}
+
+// generation is the state of a Generate.
+type generation struct {
+	structs  []*schema.Schema
+	names    map[*schema.Schema]string
+	used     map[string]bool
+	imports  map[string]bool
+	visiting map[*schema.Schema]bool
+	vars     bytes.Buffer
+	body     bytes.Buffer
+	err      error
+}
+
+// identifier returns the unused identifier based on name.
+func (p *generation) identifier(name string) string {
+	id := name
+	for i := 2; p.used[id]; i++ {
+		id = name + strconv.Itoa(i)
+	}
+	p.used[id] = true
+	return id
+}
+
+// define names the struct type of s.
+func (p *generation) define(s *schema.Schema, name string) string {
+	if name == "" {
+		name = "Object"
+	}
+	name = p.identifier(name)
+	p.names[s] = name
+	p.structs = append(p.structs, s)
+	return name
+}
+
+// deref follows the $ref of s, which has no other validation keywords.
+func (p *generation) deref(s *schema.Schema) (*schema.Schema, error) {
+	seen := map[*schema.Schema]bool{}
+	for s.RefSchema() != nil {
+		if ks := validationKeywords(s); len(ks) > 0 {
+			return nil, &UnsupportedKeywordError{s.Location, "$ref"}
+		}
+		if seen[s] {
+			return nil, RecursiveSchemaError
+		}
+		seen[s] = true
+		s = s.RefSchema()
+	}
+	return s, nil
+}
+
+// unwrap follows the $ref of s, and unwraps anyOf of a schema and the null
+// type, which Reflect of the validator package generates for nullable refs.
+func (p *generation) unwrap(s *schema.Schema) (*schema.Schema, bool, error) {
+	s, err := p.deref(s)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(s.AnyOf) != 2 || len(validationKeywords(s)) != 1 {
+		return s, false, nil
+	}
+	for i, sub := range s.AnyOf {
+		if ks := validationKeywords(sub); len(ks) == 1 && len(sub.Types) == 1 && sub.Types[0] == "null" {
+			s, _, err := p.unwrap(s.AnyOf[1-i])
+			return s, true, err
+		}
+	}
+	return s, false, nil
+}
+
+// goType returns the Go type of s, and whether the value may be null.
+// The hint is the name of the struct type when s is an object with properties.
+func (p *generation) goType(s *schema.Schema, hint string) (string, bool, error) {
+	s, null, err := p.unwrap(s)
+	if err != nil {
+		return "", false, err
+	}
+	if p.visiting[s] {
+		return "", false, RecursiveSchemaError
+	}
+	p.visiting[s] = true
+	defer delete(p.visiting, s)
+
+	kind, nullable := kindOf(s)
+	nullable = nullable || null
+	switch kind {
+	case "string":
+		return "string", nullable, nil
+	case "integer":
+		return "int", nullable, nil
+	case "number":
+		return "float64", nullable, nil
+	case "boolean":
+		return "bool", nullable, nil
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}", false, nil
+		}
+		t, null, err := p.goType(s.Items, hint+"Item")
+		if err != nil {
+			return "", false, err
+		}
+		return "[]" + pointer(t, null), false, nil
+	case "object":
+		if isStruct(s) {
+			if name, ok := p.names[s]; ok {
+				return name, nullable, nil
+			}
+			return p.define(s, hint), nullable, nil
+		}
+		if s.AdditionalProperties == nil || s.AdditionalProperties.Boolean != nil {
+			return "map[string]interface{}", false, nil
+		}
+		t, null, err := p.goType(s.AdditionalProperties, hint+"Value")
+		if err != nil {
+			return "", false, err
+		}
+		return "map[string]" + pointer(t, null), false, nil
+	}
+	return "interface{}", false, nil
+}
+
+// structType writes the declaration and the Validate method of the struct type of s.
+func (p *generation) structType(s *schema.Schema) error {
+	name := p.names[s]
+	if err := checkKeywords(s, "object"); err != nil {
+		return err
+	}
+
+	type field struct {
+		name, typ, tag string
+		pointer        bool
+		schema         *schema.Schema
+		property       string
+	}
+	var fields []field
+	fieldNames := map[string]bool{}
+	for _, property := range sortedKeys(s.Properties) {
+		base := exported(property)
+		if base == "" {
+			base = "Field"
+		}
+		fieldName := base
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = base + strconv.Itoa(i)
+		}
+		fieldNames[fieldName] = true
+
+		t, nullable, err := p.goType(s.Properties[property], name+fieldName)
+		if err != nil {
+			return err
+		}
+		tag := property
+		required := contains(s.Required, property)
+		if !required {
+			tag += ",omitempty"
+		}
+		ptr := (nullable || !required) && pointable(t)
+		if ptr {
+			t = "*" + t
+		}
+		fields = append(fields, field{fieldName, t, tag, ptr, s.Properties[property], property})
+	}
+
+	w := &p.body
+	fmt.Fprintf(w, "// %s is generated from the schema at '%s'.\n", name, s.Location)
+	if s.Description != "" {
+		w.WriteString("//\n")
+		for _, line := range strings.Split(strings.TrimSpace(s.Description), "\n") {
+			fmt.Fprintf(w, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, f := range fields {
+		fmt.Fprintf(w, "%s %s `json:%q`\n", f.name, f.typ, f.tag)
+	}
+	prefix := unexported(name)
+	required := ""
+	if len(s.Required) > 0 {
+		w.WriteString("// missing is the required properties which the decoded JSON doesn't have.\n")
+		w.WriteString("missing []string\n")
+		required = p.identifier(prefix + "Required")
+		fmt.Fprintf(&p.vars, "%s = %#v\n", required, s.Required)
+	}
+	closed := isFalse(s.AdditionalProperties)
+	if closed {
+		w.WriteString("// unknown is the properties of the decoded JSON which additionalProperties doesn't allow.\n")
+		w.WriteString("unknown []string\n")
+	}
+	w.WriteString("}\n\n")
+
+	if required != "" || closed {
+		p.imports["encoding/json"] = true
+		var records []string
+		if required != "" {
+			records = append(records, "the required properties which data doesn't have")
+		}
+		if closed {
+			records = append(records, "the properties which additionalProperties doesn't allow")
+		}
+		fmt.Fprintf(w, "// UnmarshalJSON decodes data into v, and records %s.\n", strings.Join(records, " and "))
+		fmt.Fprintf(w, "func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+		w.WriteString("if string(data) == \"null\" {\nreturn nil\n}\n")
+		fmt.Fprintf(w, "type plain %s\n", name)
+		w.WriteString("var properties map[string]json.RawMessage\n")
+		w.WriteString("if err := json.Unmarshal(data, &properties); err != nil {\nreturn err\n}\n")
+		w.WriteString("if err := json.Unmarshal(data, (*plain)(v)); err != nil {\nreturn err\n}\n")
+		if required != "" {
+			w.WriteString("v.missing = nil\n")
+			fmt.Fprintf(w, "for _, property := range %s {\n", required)
+			w.WriteString("if _, ok := properties[property]; !ok {\nv.missing = append(v.missing, property)\n}\n}\n")
+		}
+		if closed {
+			p.imports["sort"] = true
+			known := make([]string, len(fields))
+			for i, f := range fields {
+				known[i] = strconv.Quote(f.property)
+			}
+			w.WriteString("v.unknown = nil\n")
+			w.WriteString("for property := range properties {\nswitch property {\n")
+			fmt.Fprintf(w, "case %s:\n", strings.Join(known, ", "))
+			w.WriteString("default:\nv.unknown = append(v.unknown, property)\n}\n}\n")
+			w.WriteString("sort.Strings(v.unknown)\n")
+		}
+		w.WriteString("return nil\n}\n\n")
+	}
+
+	fmt.Fprintf(w, "// Validate returns the errors of v against the schema of %s.\n", name)
+	fmt.Fprintf(w, "func (v *%s) Validate() error {\n", name)
+	w.WriteString("if errs := v.validate(); len(errs) > 0 {\nreturn errs\n}\nreturn nil\n}\n\n")
+	fmt.Fprintf(w, "func (v *%s) validate() schema.ValidationErrors {\n", name)
+	w.WriteString("var errs schema.ValidationErrors\n")
+	p.imports["schema"] = true
+	if required != "" {
+		w.WriteString("for _, property := range v.missing {\n")
+		p.fail(w, pathExpr{}, s, "required", fmt.Sprintf("&schema.RequiredValidationError{Required: %s, Property: property}", required))
+		w.WriteString("}\n")
+	}
+	if closed {
+		w.WriteString("for _, property := range v.unknown {\n")
+		p.fail(w, pathExpr{}, s, "additionalProperties", "&schema.AdditionalPropertiesValidationError{Property: property}")
+		w.WriteString("}\n")
+	}
+	for _, f := range fields {
+		path := pathExpr{}.literal("/" + schema.EscapePointer(f.property))
+		if err := p.check(w, "v."+f.name, path, prefix+f.name, f.schema, f.pointer, 1); err != nil {
+			return err
+		}
+	}
+	w.WriteString("return errs\n}\n\n")
+	return nil
+}
+
+// check writes the validation of the Go expression expr, whose schema is s.
+// The path is the instance path of the value, and the name is the prefix
+// of the names of the validators. The expr is a pointer if ptr is true.
+func (p *generation) check(w *bytes.Buffer, expr string, path pathExpr, name string, s *schema.Schema, ptr bool, depth int) error {
+	s, _, err := p.unwrap(s)
+	if err != nil {
+		return err
+	}
+	kind, _ := kindOf(s)
+	if err := checkKeywords(s, kind); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	value := expr
+	if ptr && kind != "object" {
+		value = "*" + expr
+	}
+	switch kind {
+	case "string":
+		err = p.checkString(&b, value, path, name, s)
+	case "integer":
+		err = p.checkInteger(&b, value, path, name, s)
+	case "number":
+		err = p.checkNumber(&b, value, path, name, s)
+	case "boolean":
+		if s.Enum != nil {
+			def := booleans.EnumValidatorDefinition{}
+			for _, e := range s.Enum {
+				v, ok := e.(bool)
+				if !ok {
+					return &UnsupportedKeywordError{s.Location, "enum"}
+				}
+				def.Enum = append(def.Enum, v)
+			}
+			_, err := booleans.NewEnumValidator(def)
+			p.validate(&b, value, path, s, "enum", name+"Enum", "booleans", "NewEnumValidator", def, err)
+		}
+	case "array":
+		err = p.checkArray(&b, expr, path, name, s, depth)
+	case "object":
+		err = p.checkObject(&b, expr, path, name, s, depth)
+	}
+	if err != nil {
+		return err
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	if ptr {
+		fmt.Fprintf(w, "if %s != nil {\n", expr)
+		b.WriteTo(w)
+		w.WriteString("}\n")
+		return nil
+	}
+	b.WriteTo(w)
+	return nil
+}
+
+func (p *generation) checkString(w *bytes.Buffer, value string, path pathExpr, name string, s *schema.Schema) error {
+	if s.MaxLength != nil {
+		def := jsstrings.MaxLengthValidatorDefinition{MaxLength: *s.MaxLength}
+		_, err := jsstrings.NewMaxLengthValidator(def)
+		p.validate(w, value, path, s, "maxLength", name+"MaxLength", "strings", "NewMaxLengthValidator", def, err)
+	}
+	if s.MinLength != nil {
+		def := jsstrings.MinLengthValidatorDefinition{MinLength: *s.MinLength}
+		_, err := jsstrings.NewMinLengthValidator(def)
+		p.validate(w, value, path, s, "minLength", name+"MinLength", "strings", "NewMinLengthValidator", def, err)
+	}
+	if s.Pattern != "" {
+		def := jsstrings.PatternValidatorDefinition{Pattern: s.Pattern}
+		_, err := jsstrings.NewPatternValidator(def)
+		p.validate(w, value, path, s, "pattern", name+"Pattern", "strings", "NewPatternValidator", def, err)
+	}
+	if s.Format != "" {
+		// The formats which the strings package doesn't know are annotations.
+		def := jsstrings.FormatValidatorDefinition{Format: s.Format}
+		if _, err := jsstrings.NewFormatValidator(def); err == nil {
+			p.validate(w, value, path, s, "format", name+"Format", "strings", "NewFormatValidator", def, nil)
+		}
+	}
+	if s.Enum != nil {
+		def := jsstrings.EnumValidatorDefinition{}
+		for _, e := range s.Enum {
+			v, ok := e.(string)
+			if !ok {
+				return &UnsupportedKeywordError{s.Location, "enum"}
+			}
+			def.Enum = append(def.Enum, v)
+		}
+		_, err := jsstrings.NewEnumValidator(def)
+		p.validate(w, value, path, s, "enum", name+"Enum", "strings", "NewEnumValidator", def, err)
+	}
+	return nil
+}
+
+func (p *generation) checkInteger(w *bytes.Buffer, value string, path pathExpr, name string, s *schema.Schema) error {
+	limits := []struct {
+		keyword   string
+		limit     *float64
+		exclusive bool
+	}{
+		{"maximum", s.Maximum, false},
+		{"exclusiveMaximum", s.ExclusiveMaximum, true},
+		{"minimum", s.Minimum, false},
+		{"exclusiveMinimum", s.ExclusiveMinimum, true},
+	}
+	for _, l := range limits {
+		if l.limit == nil {
+			continue
+		}
+		n, ok := toInt(*l.limit)
+		if !ok {
+			return &UnsupportedKeywordError{s.Location, l.keyword}
+		}
+		id := name + exported(l.keyword)
+		if strings.HasSuffix(l.keyword, "aximum") {
+			def := integers.MaximumValidatorDefinition{Maximum: n, Exclusive: l.exclusive}
+			p.validate(w, value, path, s, l.keyword, id, "integers", "NewMaximumValidator", def, nil)
+		} else {
+			def := integers.MinimumValidatorDefinition{Minimum: n, Exclusive: l.exclusive}
+			p.validate(w, value, path, s, l.keyword, id, "integers", "NewMinimumValidator", def, nil)
+		}
+	}
+	if s.MultipleOf != nil {
+		if n, ok := toInt(*s.MultipleOf); ok {
+			fmt.Fprintf(w, "if %s%%%d != 0 {\n", value, n)
+			p.fail(w, path, s, "multipleOf", fmt.Sprintf("&schema.MultipleOfValidationError{MultipleOf: %d, Input: float64(%s)}", n, value))
+			w.WriteString("}\n")
+		} else {
+			p.multipleOf(w, "float64("+value+")", path, name, s)
+		}
+	}
+	if s.Enum != nil {
+		def := integers.EnumValidatorDefinition{}
+		for _, e := range s.Enum {
+			f, ok := toFloat(e)
+			n, isInt := toInt(f)
+			if !ok || !isInt {
+				return &UnsupportedKeywordError{s.Location, "enum"}
+			}
+			def.Enum = append(def.Enum, n)
+		}
+		_, err := integers.NewEnumValidator(def)
+		p.validate(w, value, path, s, "enum", name+"Enum", "integers", "NewEnumValidator", def, err)
+	}
+	return nil
+}
+
+func (p *generation) checkNumber(w *bytes.Buffer, value string, path pathExpr, name string, s *schema.Schema) error {
+	if s.Maximum != nil {
+		def := numbers.MaximumValidatorDefinition{Maximum: *s.Maximum}
+		p.validate(w, value, path, s, "maximum", name+"Maximum", "numbers", "NewMaximumValidator", def, nil)
+	}
+	if s.ExclusiveMaximum != nil {
+		def := numbers.MaximumValidatorDefinition{Maximum: *s.ExclusiveMaximum, Exclusive: true}
+		p.validate(w, value, path, s, "exclusiveMaximum", name+"ExclusiveMaximum", "numbers", "NewMaximumValidator", def, nil)
+	}
+	if s.Minimum != nil {
+		def := numbers.MinimumValidatorDefinition{Minimum: *s.Minimum}
+		p.validate(w, value, path, s, "minimum", name+"Minimum", "numbers", "NewMinimumValidator", def, nil)
+	}
+	if s.ExclusiveMinimum != nil {
+		def := numbers.MinimumValidatorDefinition{Minimum: *s.ExclusiveMinimum, Exclusive: true}
+		p.validate(w, value, path, s, "exclusiveMinimum", name+"ExclusiveMinimum", "numbers", "NewMinimumValidator", def, nil)
+	}
+	if s.MultipleOf != nil {
+		p.multipleOf(w, value, path, name, s)
+	}
+	if s.Enum != nil {
+		def := numbers.EnumValidatorDefinition{}
+		for _, e := range s.Enum {
+			f, ok := toFloat(e)
+			if !ok {
+				return &UnsupportedKeywordError{s.Location, "enum"}
+			}
+			def.Enum = append(def.Enum, f)
+		}
+		_, err := numbers.NewEnumValidator(def)
+		p.validate(w, value, path, s, "enum", name+"Enum", "numbers", "NewEnumValidator", def, err)
+	}
+	return nil
+}
+
+// multipleOf writes the multipleOf check of the float64 expression value,
+// which is compared exactly in its shortest decimal form like Validate of
+// Schema, so that 19.99 is a multiple of 0.01.
+func (p *generation) multipleOf(w *bytes.Buffer, value string, path pathExpr, name string, s *schema.Schema) {
+	m := strconv.FormatFloat(*s.MultipleOf, 'g', -1, 64)
+	def := bignumbers.MultipleOfValidatorDefinition{MultipleOf: json.Number(m)}
+	if _, err := bignumbers.NewMultipleOfValidator(def); err != nil {
+		if p.err == nil {
+			p.err = &schema.DefinitionError{Location: s.Location + "/multipleOf", Keyword: "multipleOf", Err: err}
+		}
+		return
+	}
+	p.imports["bignumbers"] = true
+	p.imports["encoding/json"] = true
+	p.imports["strconv"] = true
+	id := p.identifier(name + "MultipleOf")
+	fmt.Fprintf(&p.vars, "%s, _ = bignumbers.NewMultipleOfValidator(%#v)\n", id, def)
+	fmt.Fprintf(w, "if err := %s.Validate(json.Number(strconv.FormatFloat(%s, 'g', -1, 64))); err != nil {\n", id, value)
+	p.fail(w, path, s, "multipleOf", fmt.Sprintf("&schema.MultipleOfValidationError{MultipleOf: %s, Input: %s}", m, value))
+	w.WriteString("}\n")
+}
+
+func (p *generation) checkArray(w *bytes.Buffer, expr string, path pathExpr, name string, s *schema.Schema, depth int) error {
+	if s.MaxItems != nil {
+		p.imports["arrays"] = true
+		fmt.Fprintf(w, "if len(%s) > %d {\n", expr, *s.MaxItems)
+		p.fail(w, path, s, "maxItems", fmt.Sprintf("&arrays.MaxItemsValidationError{Definition: arrays.MaxItemsValidatorDefinition{MaxItems: %d}, Input: %s}", *s.MaxItems, expr))
+		w.WriteString("}\n")
+	}
+	if s.MinItems != nil {
+		p.imports["arrays"] = true
+		fmt.Fprintf(w, "if len(%s) < %d {\n", expr, *s.MinItems)
+		p.fail(w, path, s, "minItems", fmt.Sprintf("&arrays.MinItemsValidationError{Definition: arrays.MinItemsValidatorDefinition{MinItems: %d}, Input: %s}", *s.MinItems, expr))
+		w.WriteString("}\n")
+	}
+	if s.Items == nil {
+		return nil
+	}
+	t, nullable, err := p.goType(s.Items, "")
+	if err != nil {
+		return err
+	}
+	i := "i" + strconv.Itoa(depth)
+	var b bytes.Buffer
+	item := path.literal("/").expr("strconv.Itoa(" + i + ")")
+	if err := p.check(&b, expr+"["+i+"]", item, name+"Items", s.Items, nullable && pointable(t), depth+1); err != nil {
+		return err
+	}
+	if b.Len() > 0 {
+		p.imports["strconv"] = true
+		fmt.Fprintf(w, "for %s := range %s {\n", i, expr)
+		b.WriteTo(w)
+		w.WriteString("}\n")
+	}
+	return nil
+}
+
+func (p *generation) checkObject(w *bytes.Buffer, expr string, path pathExpr, name string, s *schema.Schema, depth int) error {
+	if isStruct(s) {
+		e := "e" + strconv.Itoa(depth)
+		fmt.Fprintf(w, "if %s := %s.validate(); len(%s) > 0 {\n", e, expr, e)
+		fmt.Fprintf(w, "errs = append(errs, %s.Prefix(%s)...)\n", e, path)
+		w.WriteString("}\n")
+		return nil
+	}
+	k := "k" + strconv.Itoa(depth)
+	if len(s.Required) > 0 {
+		required := p.identifier(name + "Required")
+		fmt.Fprintf(&p.vars, "%s = %#v\n", required, s.Required)
+		fmt.Fprintf(w, "for _, %s := range %s {\n", k, required)
+		fmt.Fprintf(w, "if _, ok := %s[%s]; !ok {\n", expr, k)
+		p.fail(w, path, s, "required", fmt.Sprintf("&schema.RequiredValidationError{Required: %s, Property: %s}", required, k))
+		w.WriteString("}\n}\n")
+	}
+	if isFalse(s.AdditionalProperties) {
+		// no property is allowed, since the object has no properties
+		p.imports["sort"] = true
+		keys := "keys" + strconv.Itoa(depth)
+		fmt.Fprintf(w, "if len(%s) > 0 {\n", expr)
+		fmt.Fprintf(w, "%s := make([]string, 0, len(%s))\n", keys, expr)
+		fmt.Fprintf(w, "for %s := range %s {\n%s = append(%s, %s)\n}\n", k, expr, keys, keys, k)
+		fmt.Fprintf(w, "sort.Strings(%s)\n", keys)
+		fmt.Fprintf(w, "for _, %s := range %s {\n", k, keys)
+		p.fail(w, path, s, "additionalProperties", "&schema.AdditionalPropertiesValidationError{Property: "+k+"}")
+		w.WriteString("}\n}\n")
+	}
+	if s.AdditionalProperties == nil || s.AdditionalProperties.Boolean != nil {
+		return nil
+	}
+	t, nullable, err := p.goType(s.AdditionalProperties, "")
+	if err != nil {
+		return err
+	}
+	// the values are copied into v, which is addressable for the pointer
+	// methods of the structs
+	v := "v" + strconv.Itoa(depth)
+	var b bytes.Buffer
+	value := path.literal("/").expr("schema.EscapePointer(" + k + ")")
+	if err := p.check(&b, v, value, name+"Values", s.AdditionalProperties, nullable && pointable(t), depth+1); err != nil {
+		return err
+	}
+	if b.Len() > 0 {
+		fmt.Fprintf(w, "for %s, %s := range %s {\n", k, v, expr)
+		b.WriteTo(w)
+		w.WriteString("}\n")
+	}
+	return nil
+}
+
+// isFalse reports whether s is the false schema.
+func isFalse(s *schema.Schema) bool {
+	return s != nil && s.Boolean != nil && !*s.Boolean
+}
+
+// validate declares the package variable of the validator of the keyword,
+// and writes its call. The err is the error of the constructor, so the
+// generated code can ignore it.
+func (p *generation) validate(w *bytes.Buffer, value string, path pathExpr, s *schema.Schema, keyword, name, pkg, constructor string, def interface{}, err error) {
+	if err != nil {
+		if p.err == nil {
+			p.err = &schema.DefinitionError{Location: s.Location + "/" + keyword, Keyword: keyword, Err: err}
+		}
+		return
+	}
+	p.imports[pkg] = true
+	id := p.identifier(name)
+	fmt.Fprintf(&p.vars, "%s, _ = %s.%s(%#v)\n", id, pkg, constructor, def)
+	fmt.Fprintf(w, "if err := %s.Validate(%s); err != nil {\n", id, value)
+	p.fail(w, path, s, keyword, "err")
+	w.WriteString("}\n")
+}
+
+// fail writes the append of the ValidationError.
+func (p *generation) fail(w *bytes.Buffer, path pathExpr, s *schema.Schema, keyword, err string) {
+	fmt.Fprintf(w, "errs = append(errs, &schema.ValidationError{InstancePath: %s, SchemaPath: %q, Keyword: %q, Err: %s})\n",
+		path, s.Location+"/"+keyword, keyword, err)
+}
+
+// source returns the formatted source code.
+func (p *generation) source(pkg string) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by jsvalidator-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n")
+	for _, std := range []string{"encoding/json", "sort", "strconv"} {
+		if p.imports[std] {
+			fmt.Fprintf(&b, "%q\n", std)
+		}
+	}
+	b.WriteString("\n")
+	for _, name := range []string{"arrays", "bignumbers", "booleans", "integers", "numbers", "schema", "strings"} {
+		if p.imports[name] {
+			fmt.Fprintf(&b, "%q\n", importPath+name)
+		}
+	}
+	b.WriteString(")\n\n")
+	if p.vars.Len() > 0 {
+		b.WriteString("var (\n")
+		p.vars.WriteTo(&b)
+		b.WriteString(")\n\n")
+	}
+	p.body.WriteTo(&b)
+	return format.Source(b.Bytes())
+}
+
+// pathExpr is the Go expression of an instance path, which is the
+// concatenation of the quoted literals and the expressions.
+type pathExpr []string
+
+func (p pathExpr) literal(s string) pathExpr {
+	p = append(pathExpr{}, p...)
+	if n := len(p); n > 0 && strings.HasPrefix(p[n-1], `"`) {
+		prev, _ := strconv.Unquote(p[n-1])
+		return append(p[:n-1], strconv.Quote(prev+s))
+	}
+	return append(p, strconv.Quote(s))
+}
+
+func (p pathExpr) expr(e string) pathExpr {
+	return append(append(pathExpr{}, p...), e)
+}
+
+func (p pathExpr) String() string {
+	if len(p) == 0 {
+		return `""`
+	}
+	return strings.Join(p, " + ")
+}
+
+// kindOf returns the type of the values of s, and whether they may be null.
+// The kind is empty when s allows several types.
+func kindOf(s *schema.Schema) (string, bool) {
+	if s.Boolean != nil {
+		return "", false
+	}
+	var kinds []string
+	nullable := false
+	for _, t := range s.Types {
+		if t == "null" {
+			nullable = true
+			continue
+		}
+		kinds = append(kinds, t)
+	}
+	if len(kinds) != 1 {
+		return "", nullable
+	}
+	return kinds[0], nullable
+}
+
+func isStruct(s *schema.Schema) bool {
+	kind, _ := kindOf(s)
+	return kind == "object" && len(s.Properties) > 0
+}
+
+// supportedKeywords are the keywords which the generated code evaluates for each kind.
+var supportedKeywords = map[string][]string{
+	"string":  {"type", "enum", "maxLength", "minLength", "pattern", "format"},
+	"integer": {"type", "enum", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "multipleOf"},
+	"number":  {"type", "enum", "maximum", "exclusiveMaximum", "minimum", "exclusiveMinimum", "multipleOf"},
+	"boolean": {"type", "enum"},
+	"array":   {"type", "items", "maxItems", "minItems"},
+	"object":  {"type", "properties", "required", "additionalProperties"},
+}
+
+// checkKeywords returns UnsupportedKeywordError if s has a keyword which
+// the generated code of kind can't evaluate.
+func checkKeywords(s *schema.Schema, kind string) error {
+	for _, k := range validationKeywords(s) {
+		if !contains(supportedKeywords[kind], k) {
+			return &UnsupportedKeywordError{s.Location, k}
+		}
+	}
+	if kind == "object" && isStruct(s) && s.AdditionalProperties != nil && s.AdditionalProperties.Boolean == nil {
+		return &UnsupportedKeywordError{s.Location, "additionalProperties"}
+	}
+	return nil
+}
+
+// validationKeywords returns the keywords of s except $ref and the annotations.
+func validationKeywords(s *schema.Schema) []string {
+	var ks []string
+	add := func(k string, ok bool) {
+		if ok {
+			ks = append(ks, k)
+		}
+	}
+	add("type", len(s.Types) > 0)
+	add("enum", s.Enum != nil)
+	add("const", s.HasConst)
+	add("multipleOf", s.MultipleOf != nil)
+	add("maximum", s.Maximum != nil)
+	add("exclusiveMaximum", s.ExclusiveMaximum != nil)
+	add("minimum", s.Minimum != nil)
+	add("exclusiveMinimum", s.ExclusiveMinimum != nil)
+	add("maxLength", s.MaxLength != nil)
+	add("minLength", s.MinLength != nil)
+	add("pattern", s.Pattern != "")
+	add("format", s.Format != "")
+	add("prefixItems", s.PrefixItems != nil)
+	add("items", s.Items != nil)
+	add("contains", s.Contains != nil)
+	add("maxItems", s.MaxItems != nil)
+	add("minItems", s.MinItems != nil)
+	add("uniqueItems", s.UniqueItems)
+	add("unevaluatedItems", s.UnevaluatedItems != nil)
+	add("properties", s.Properties != nil)
+	add("patternProperties", s.PatternProperties != nil)
+	add("additionalProperties", s.AdditionalProperties != nil)
+	add("propertyNames", s.PropertyNames != nil)
+	add("required", s.Required != nil)
+	add("dependentRequired", s.DependentRequired != nil)
+	add("dependentSchemas", s.DependentSchemas != nil)
+	add("maxProperties", s.MaxProperties != nil)
+	add("minProperties", s.MinProperties != nil)
+	add("unevaluatedProperties", s.UnevaluatedProperties != nil)
+	add("allOf", s.AllOf != nil)
+	add("anyOf", s.AnyOf != nil)
+	add("oneOf", s.OneOf != nil)
+	add("not", s.Not != nil)
+	add("if", s.If != nil)
+	add("$dynamicRef", s.DynamicRef != "")
+	return ks
+}
+
+// pointable returns whether the values of the Go type t can't be nil by themselves.
+func pointable(t string) bool {
+	return !strings.HasPrefix(t, "[]") && !strings.HasPrefix(t, "map[") && t != "interface{}"
+}
+
+func pointer(t string, nullable bool) string {
+	if nullable && pointable(t) {
+		return "*" + t
+	}
+	return t
+}
+
+// commonInitialisms are written in upper case in the Go identifiers.
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "URI": true, "URL": true, "UUID": true,
+}
+
+// exported converts the name in JSON to the exported Go identifier.
+func exported(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b bytes.Buffer
+	for _, w := range words {
+		if u := strings.ToUpper(w); commonInitialisms[u] {
+			b.WriteString(u)
+			continue
+		}
+		rs := []rune(w)
+		b.WriteString(strings.ToUpper(string(rs[0])) + string(rs[1:]))
+	}
+	id := b.String()
+	if id != "" && unicode.IsDigit([]rune(id)[0]) {
+		id = "X" + id
+	}
+	return id
+}
+
+// unexported converts the exported Go identifier to the unexported one.
+func unexported(name string) string {
+	rs := []rune(name)
+	i := 0
+	for i < len(rs) && unicode.IsUpper(rs[i]) {
+		i++
+	}
+	if i > 1 && i < len(rs) {
+		i--
+	}
+	return strings.ToLower(string(rs[:i])) + string(rs[i:])
+}
+
+// toFloat returns the number of the JSON value decoded by the schema package.
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case interface{ Float64() (float64, error) }:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func contains(ss []string, s string) bool {
+	for _, e := range ss {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]*schema.Schema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
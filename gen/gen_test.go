@@ -0,0 +1,190 @@
+package gen_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/gen"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+// TestGenerateExample checks that the generated code of the example package is up to date.
+func TestGenerateExample(t *testing.T) {
+	data, err := ioutil.ReadFile("example/article.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := schema.Load(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := gen.NewGenerator("example", "Article").Generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := ioutil.ReadFile("example/article_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(actual, expected) {
+		t.Error("example/article_gen.go is out of date; run go generate ./gen/example")
+	}
+}
+
+const objectsDocument = `{
+	"type": "object",
+	"properties": {
+		"items": {
+			"type": "object",
+			"additionalProperties": {
+				"type": "object",
+				"properties": {"name": {"type": "string", "maxLength": 3}}
+			}
+		},
+		"empty": {"type": "object", "required": ["a"], "additionalProperties": false},
+		"closed": {
+			"type": "object",
+			"properties": {"a": {"type": "string"}},
+			"additionalProperties": false
+		}
+	}
+}`
+
+const objectsTest = `package objects
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestRootValidate(t *testing.T) {
+	var r Root
+	data := ` + "`" + `{"items": {"x": {"name": "long"}}, "empty": {"b": 1}, "closed": {"a": "a", "c": 1, "b": 2}}` + "`" + `
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		t.Fatal(err)
+	}
+	var actual []string
+	errs, _ := r.Validate().(schema.ValidationErrors)
+	for _, e := range errs {
+		actual = append(actual, e.InstancePath+" "+e.Keyword)
+	}
+	sort.Strings(actual)
+	expected := []string{
+		"/closed additionalProperties",
+		"/closed additionalProperties",
+		"/empty additionalProperties",
+		"/empty required",
+		"/items/x/name maxLength",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
+`
+
+// TestGenerateObjects builds and tests the generated code of the objects
+// with additionalProperties, which the example doesn't have.
+func TestGenerateObjects(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not found")
+	}
+	s, err := schema.Load([]byte(objectsDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := gen.NewGenerator("objects", "Root").Generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the package is in the tree, so it imports the packages of the tree,
+	// and the go command ignores it for the prefix "_"
+	dir, err := ioutil.TempDir(".", "_objects")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "root_gen.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "root_test.go"), []byte(objectsTest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goTool, "test", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("the generated code fails: %s\n%s", err, out)
+	}
+}
+
+func TestGenerateWithErrors(t *testing.T) {
+	type Case struct {
+		Message  string
+		Type     string
+		Document string
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message:  "no type name",
+			Document: `{"type": "object", "properties": {"a": {"type": "string"}}}`,
+			Error:    gen.TypeNameEmptyError,
+		},
+		{
+			Message:  "root is not an object",
+			Type:     "Root",
+			Document: `{"type": "string"}`,
+			Error:    gen.RootNotStructError,
+		},
+		{
+			Message:  "unsupported keyword",
+			Type:     "Root",
+			Document: `{"type": "object", "properties": {"a": {"oneOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+			Error:    &gen.UnsupportedKeywordError{Location: "#/properties/a", Keyword: "oneOf"},
+		},
+		{
+			Message:  "keyword without type",
+			Type:     "Root",
+			Document: `{"type": "object", "properties": {"a": {"maxLength": 1}}}`,
+			Error:    &gen.UnsupportedKeywordError{Location: "#/properties/a", Keyword: "maxLength"},
+		},
+		{
+			Message:  "non-integer limit of integer",
+			Type:     "Root",
+			Document: `{"type": "object", "properties": {"a": {"type": "integer", "maximum": 1.5}}}`,
+			Error:    &gen.UnsupportedKeywordError{Location: "#/properties/a", Keyword: "maximum"},
+		},
+		{
+			Message:  "mixed enum",
+			Type:     "Root",
+			Document: `{"type": "object", "properties": {"a": {"type": "string", "enum": ["a", 1]}}}`,
+			Error:    &gen.UnsupportedKeywordError{Location: "#/properties/a", Keyword: "enum"},
+		},
+		{
+			Message:  "unevaluatedProperties",
+			Type:     "Root",
+			Document: `{"type": "object", "properties": {"a": {"type": "string"}}, "unevaluatedProperties": false}`,
+			Error:    &gen.UnsupportedKeywordError{Location: "#", Keyword: "unevaluatedProperties"},
+		},
+	}
+
+	for _, c := range cases {
+		s, err := schema.Load([]byte(c.Document))
+		if err != nil {
+			t.Fatalf("%s: %s", c.Message, err)
+		}
+		_, err = gen.NewGenerator("test", c.Type).Generate(s)
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
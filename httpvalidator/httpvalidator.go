@@ -0,0 +1,339 @@
+// Package httpvalidator validates HTTP requests against JSON Schema in a net/http middleware.
+package httpvalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+var (
+	BodyRequiredError = errors.New("the request body is required")
+	InvalidJSONError  = errors.New("the body is not a valid JSON")
+	InvalidFormError  = errors.New("the body is not a valid form")
+	BodyTooLargeError = errors.New("the request body is too large")
+)
+
+// Location of the values of a request, which is reported in Error.
+const (
+	InBody     = "body"
+	InQuery    = "query"
+	InHeader   = "header"
+	InResponse = "response"
+)
+
+// Route is the schemas of the requests to a route.
+// The nil schemas are not validated.
+type Route struct {
+	// Body is the schema of the JSON request body, or of the object of the
+	// fields of the application/x-www-form-urlencoded body like Query.
+	Body *schema.Schema
+	// OptionalBody allows the requests without the body, which are not validated against Body.
+	OptionalBody bool
+	// Query is the schema of the object of the query parameters.
+	// The parameters are strings, or arrays of strings when they are repeated,
+	// unless Coerce of the Validator converts them.
+	Query *schema.Schema
+	// Header is the schema of the object of the headers whose names are in
+	// lower case. The headers are strings, or arrays of strings when they are repeated.
+	Header *schema.Schema
+	// Responses are the schemas of the JSON response bodies by the status codes.
+	// The schema for 0 is used for the status codes which are not in it.
+	Responses map[int]*schema.Schema
+}
+
+// Error is an error of a request rendered in the response.
+type Error struct {
+	In           string `json:"in"`
+	InstancePath string `json:"instance_path"`
+	SchemaPath   string `json:"schema_path,omitempty"`
+	Keyword      string `json:"keyword,omitempty"`
+	Message      string `json:"message"`
+}
+
+// ErrorHandler writes the response for the invalid request.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, status int, errs []*Error)
+
+// Validator is a middleware which validates the requests to its routes.
+type Validator struct {
+	// Status is the status code of the requests which don't conform to
+	// the schemas. The requests whose body is not JSON are 400 Bad Request.
+	Status int
+	// Problem renders the errors as RFC 7807 application/problem+json.
+	Problem bool
+	// MaxBodyBytes is the maximum size of the request bodies which are
+	// validated. The larger requests are 413 Request Entity Too Large.
+	// The size is not limited when it is 0.
+	MaxBodyBytes int64
+	// Coerce converts the query parameters, the headers and the form fields,
+	// which are strings, to the types of their schemas before the validation.
+	// See Coerce of schema.Schema.
+	Coerce bool
+	// ErrorHandler overrides the response for the invalid requests.
+	ErrorHandler ErrorHandler
+	// Messages returns the catalog of the messages of the errors for the
+	// request, such as AcceptLanguage. The messages are in English when it
+	// is nil.
+	Messages func(r *http.Request) *messages.Catalog
+	// ResponseSampleRate is the ratio of the responses which are validated
+	// against Responses of the routes, from 0 to 1.
+	ResponseSampleRate float64
+	// ResponseErrorHandler reports the errors of the invalid responses,
+	// which have already been sent. They are logged by default.
+	ResponseErrorHandler func(r *http.Request, status int, errs []*Error)
+
+	routes map[string]*Route
+}
+
+func New() *Validator {
+	return &Validator{
+		Status: http.StatusUnprocessableEntity,
+		routes: map[string]*Route{},
+	}
+}
+
+// Route registers the schemas of the requests with method to path.
+// The path matches URL.Path exactly, and the empty method matches any method.
+func (v *Validator) Route(method, path string, route *Route) {
+	v.routes[method+" "+path] = route
+}
+
+// Middleware returns the handler which validates the requests to the routes,
+// and passes the valid requests to next. The requests to the other paths are
+// passed through.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, ok := v.routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			route, ok = v.routes[" "+r.URL.Path]
+		}
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		v.serve(route, next, w, r)
+	})
+}
+
+// Handler returns the handler which validates the requests against route,
+// for the routers which match the routes by themselves.
+func (v *Validator) Handler(route *Route, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v.serve(route, next, w, r)
+	})
+}
+
+func (v *Validator) serve(route *Route, next http.Handler, w http.ResponseWriter, r *http.Request) {
+	c := messages.English
+	if v.Messages != nil {
+		c = v.Messages(r)
+	}
+	var errs []*Error
+	if route.Query != nil {
+		_, errs = v.validate(c, InQuery, route.Query, values(r.URL.Query()), true, errs)
+	}
+	if route.Header != nil {
+		header := make(map[string][]string, len(r.Header))
+		for name, vs := range r.Header {
+			header[strings.ToLower(name)] = vs
+		}
+		_, errs = v.validate(c, InHeader, route.Header, values(header), true, errs)
+	}
+	if route.Body != nil {
+		body, form, err := decodeBody(w, r, v.MaxBodyBytes)
+		switch {
+		case err == BodyRequiredError && route.OptionalBody:
+		case err == BodyTooLargeError:
+			v.fail(w, r, http.StatusRequestEntityTooLarge, []*Error{{In: InBody, Message: err.Error()}})
+			return
+		case err != nil:
+			v.fail(w, r, http.StatusBadRequest, []*Error{{In: InBody, Message: err.Error()}})
+			return
+		default:
+			body, errs = v.validate(c, InBody, route.Body, body, form, errs)
+			r = r.WithContext(context.WithValue(r.Context(), bodyKey{}, &decodedBody{body}))
+		}
+	}
+	if len(errs) > 0 {
+		v.fail(w, r, v.Status, errs)
+		return
+	}
+	if route.Responses == nil || v.ResponseSampleRate <= 0 || rand.Float64() >= v.ResponseSampleRate {
+		next.ServeHTTP(w, r)
+		return
+	}
+
+	rec := &responseRecorder{ResponseWriter: w}
+	next.ServeHTTP(rec, r)
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	if errs := ValidateResponseWith(c, route, rec.status, rec.body.Bytes()); len(errs) > 0 {
+		if v.ResponseErrorHandler != nil {
+			v.ResponseErrorHandler(r, rec.status, errs)
+			return
+		}
+		for _, e := range errs {
+			log.Printf("httpvalidator: invalid response %d for %s %s: %s: %s", rec.status, r.Method, r.URL.Path, e.InstancePath, e.Message)
+		}
+	}
+}
+
+// validate validates instance against s, and appends the errors in the
+// catalog c to errs. The instance is converted when coerce is true and
+// Coerce is enabled.
+func (v *Validator) validate(c *messages.Catalog, in string, s *schema.Schema, instance interface{}, coerce bool, errs []*Error) (interface{}, []*Error) {
+	var err error
+	if coerce && v.Coerce {
+		instance, err = s.ValidateWithCoercion(instance)
+	} else {
+		err = s.Validate(instance)
+	}
+	return instance, append(errs, convertErrors(c, in, err)...)
+}
+
+func (v *Validator) fail(w http.ResponseWriter, r *http.Request, status int, errs []*Error) {
+	if v.ErrorHandler != nil {
+		v.ErrorHandler(w, r, status, errs)
+		return
+	}
+	if status == 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	if !v.Problem {
+		writeJSON(w, "application/json", status, struct {
+			Errors []*Error `json:"errors"`
+		}{errs})
+		return
+	}
+	writeJSON(w, "application/problem+json", status, struct {
+		Type   string   `json:"type"`
+		Title  string   `json:"title"`
+		Status int      `json:"status"`
+		Detail string   `json:"detail"`
+		Errors []*Error `json:"errors"`
+	}{"about:blank", http.StatusText(status), status, "the request doesn't conform to the schema", errs})
+}
+
+type bodyKey struct{}
+
+// decodedBody wraps the body in the context, so that the JSON null is distinguished.
+type decodedBody struct {
+	value interface{}
+}
+
+// Body returns the request body which the Validator has decoded and validated.
+// The numbers in it are json.Number. The form body is the object of the
+// fields like the query parameters, which are converted with Coerce.
+func Body(r *http.Request) (interface{}, bool) {
+	b, ok := r.Context().Value(bodyKey{}).(*decodedBody)
+	if !ok {
+		return nil, false
+	}
+	return b.value, true
+}
+
+// decodeBody decodes the JSON or the form request body, and restores the
+// body so that the handlers can read it again. The form reports whether
+// the body is a form. The body larger than max bytes is BodyTooLargeError
+// unless max is 0.
+func decodeBody(w http.ResponseWriter, r *http.Request, max int64) (body interface{}, form bool, err error) {
+	if r.Body == nil {
+		return nil, false, BodyRequiredError
+	}
+	reader := r.Body
+	if max > 0 {
+		reader = http.MaxBytesReader(w, r.Body, max)
+	}
+	data, err := ioutil.ReadAll(reader)
+	r.Body.Close()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, false, BodyTooLargeError
+		}
+		return nil, false, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, false, BodyRequiredError
+	}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		params, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, true, InvalidFormError
+		}
+		return values(params), true, nil
+	}
+	body, err = decodeJSON(data)
+	return body, false, err
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var body interface{}
+	if err := d.Decode(&body); err != nil {
+		return nil, InvalidJSONError
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, InvalidJSONError
+	}
+	return body, nil
+}
+
+// values converts the parameters to the JSON object.
+func values(params map[string][]string) map[string]interface{} {
+	m := make(map[string]interface{}, len(params))
+	for name, vs := range params {
+		if len(vs) == 1 {
+			m[name] = vs[0]
+			continue
+		}
+		a := make([]interface{}, len(vs))
+		for i, v := range vs {
+			a[i] = v
+		}
+		m[name] = a
+	}
+	return m
+}
+
+// AcceptLanguage returns the catalog of the Accept-Language header of r,
+// for Messages of Validator.
+func AcceptLanguage(r *http.Request) *messages.Catalog {
+	return messages.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+func convertErrors(c *messages.Catalog, in string, err error) []*Error {
+	errs, _ := err.(schema.ValidationErrors)
+	converted := make([]*Error, len(errs))
+	for i, e := range errs {
+		converted[i] = &Error{
+			In:           in,
+			InstancePath: e.InstancePath,
+			SchemaPath:   e.SchemaPath,
+			Keyword:      e.Keyword,
+			Message:      e.Message(c),
+		}
+	}
+	return converted
+}
+
+func writeJSON(w http.ResponseWriter, contentType string, status int, v interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,324 @@
+package httpvalidator_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/httpvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func mustLoad(t *testing.T, document string) *schema.Schema {
+	s, err := schema.Load([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestMiddleware(t *testing.T) {
+	v := httpvalidator.New()
+	v.Route("POST", "/users", &httpvalidator.Route{
+		Body: mustLoad(t, `{
+			"type": "object",
+			"properties": {"name": {"type": "string", "maxLength": 5}, "age": {"type": "integer"}},
+			"required": ["name"]
+		}`),
+		Query:  mustLoad(t, `{"properties": {"dry_run": {"enum": ["true", "false"]}}}`),
+		Header: mustLoad(t, `{"required": ["x-request-id"]}`),
+	})
+
+	var body interface{}
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = httpvalidator.Body(r)
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Write(data)
+	}))
+
+	type Case struct {
+		Message string
+		Method  string
+		Target  string
+		Header  map[string]string
+		Body    string
+		Status  int
+		Errors  []string
+	}
+	cases := []Case{
+		{
+			Message: "valid request",
+			Method:  "POST",
+			Target:  "/users?dry_run=true",
+			Header:  map[string]string{"X-Request-ID": "1"},
+			Body:    `{"name": "Alice", "age": 20}`,
+			Status:  http.StatusOK,
+		},
+		{
+			Message: "other route",
+			Method:  "GET",
+			Target:  "/users",
+			Status:  http.StatusOK,
+		},
+		{
+			Message: "invalid request",
+			Method:  "POST",
+			Target:  "/users?dry_run=yes",
+			Body:    `{"name": "Alexander", "age": 1.5}`,
+			Status:  http.StatusUnprocessableEntity,
+			Errors: []string{
+				"query /dry_run enum",
+				"header  required",
+				"body /age type",
+				"body /name maxLength",
+			},
+		},
+		{
+			Message: "malformed body",
+			Method:  "POST",
+			Target:  "/users",
+			Header:  map[string]string{"X-Request-ID": "1"},
+			Body:    `{"name":`,
+			Status:  http.StatusBadRequest,
+			Errors:  []string{"body  "},
+		},
+		{
+			Message: "empty body",
+			Method:  "POST",
+			Target:  "/users",
+			Header:  map[string]string{"X-Request-ID": "1"},
+			Status:  http.StatusBadRequest,
+			Errors:  []string{"body  "},
+		},
+	}
+
+	for _, c := range cases {
+		body = nil
+		r := httptest.NewRequest(c.Method, c.Target, strings.NewReader(c.Body))
+		for name, value := range c.Header {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != c.Status {
+			t.Errorf("%s: expected status %d, but actual %d", c.Message, c.Status, w.Code)
+			continue
+		}
+		if c.Status == http.StatusOK {
+			if w.Body.String() != c.Body {
+				t.Errorf("%s: expected the body %q for the handler, but actual %q", c.Message, c.Body, w.Body.String())
+			}
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected application/json, but actual %s", c.Message, ct)
+		}
+		var res struct {
+			Errors []*httpvalidator.Error `json:"errors"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("%s: %s", c.Message, err)
+		}
+		var actual []string
+		for _, e := range res.Errors {
+			actual = append(actual, e.In+" "+e.InstancePath+" "+e.Keyword)
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/users", strings.NewReader(`{"name": "Bob", "age": 3}`))
+	r.Header.Set("X-Request-ID", "1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	expected := map[string]interface{}{"name": "Bob", "age": json.Number("3")}
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("expected the body %v in the context, but actual %v", expected, body)
+	}
+}
+
+func TestMiddlewareWithCoercion(t *testing.T) {
+	v := httpvalidator.New()
+	v.Coerce = true
+	v.Route("POST", "/search", &httpvalidator.Route{
+		Query: mustLoad(t, `{
+			"properties": {"limit": {"type": "integer", "maximum": 100}, "ids": {"type": "array", "items": {"type": "integer"}}}
+		}`),
+		Header: mustLoad(t, `{"properties": {"x-dry-run": {"type": "boolean"}}}`),
+		Body: mustLoad(t, `{
+			"properties": {"page": {"type": "integer"}, "exact": {"type": "boolean"}},
+			"required": ["page"]
+		}`),
+	})
+	var body interface{}
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = httpvalidator.Body(r)
+	}))
+
+	type Case struct {
+		Message     string
+		Target      string
+		Header      map[string]string
+		ContentType string
+		Body        string
+		Errors      []string
+	}
+	cases := []Case{
+		{
+			Message:     "valid request",
+			Target:      "/search?limit=10&ids=1&ids=2",
+			Header:      map[string]string{"X-Dry-Run": "true"},
+			ContentType: "application/x-www-form-urlencoded",
+			Body:        "page=2&exact=false",
+		},
+		{
+			Message:     "invalid request",
+			Target:      "/search?limit=1000&ids=1,a",
+			Header:      map[string]string{"X-Dry-Run": "1"},
+			ContentType: "application/x-www-form-urlencoded",
+			Body:        "page=first",
+			Errors: []string{
+				"query /ids/1 type",
+				"header /x-dry-run type",
+				"body /page type",
+			},
+		},
+		{
+			Message:     "converted values are validated",
+			Target:      "/search?limit=1000",
+			ContentType: "application/x-www-form-urlencoded",
+			Body:        "page=1",
+			Errors:      []string{"query /limit maximum"},
+		},
+		{
+			Message:     "JSON body is not converted",
+			Target:      "/search",
+			ContentType: "application/json",
+			Body:        `{"page": "2"}`,
+			Errors:      []string{"body /page type"},
+		},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("POST", c.Target, strings.NewReader(c.Body))
+		r.Header.Set("Content-Type", c.ContentType)
+		for name, value := range c.Header {
+			r.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		var res struct {
+			Errors []*httpvalidator.Error `json:"errors"`
+		}
+		if w.Code != http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatalf("%s: %s", c.Message, err)
+			}
+		}
+		var actual []string
+		for _, e := range res.Errors {
+			actual = append(actual, e.In+" "+e.InstancePath+" "+e.Keyword)
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+
+	expected := map[string]interface{}{"page": json.Number("2"), "exact": false}
+	r := httptest.NewRequest("POST", "/search", strings.NewReader("page=2&exact=false"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if !reflect.DeepEqual(body, expected) {
+		t.Errorf("expected the converted body %v in the context, but actual %v", expected, body)
+	}
+}
+
+func TestHandlerWithProblem(t *testing.T) {
+	v := httpvalidator.New()
+	v.Problem = true
+	v.Status = http.StatusBadRequest
+	handler := v.Handler(&httpvalidator.Route{Body: mustLoad(t, `{"type": "array"}`)},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("PUT", "/anything", strings.NewReader(`{}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, but actual %d", http.StatusBadRequest, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("expected application/problem+json, but actual %s", ct)
+	}
+	var problem map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem["status"] != float64(http.StatusBadRequest) || problem["title"] != "Bad Request" {
+		t.Errorf("unexpected problem %v", problem)
+	}
+	if errs, ok := problem["errors"].([]interface{}); !ok || len(errs) != 1 {
+		t.Errorf("expected an error, but actual %v", problem["errors"])
+	}
+}
+
+func TestHandlerWithMessages(t *testing.T) {
+	v := httpvalidator.New()
+	v.Messages = httpvalidator.AcceptLanguage
+	handler := v.Handler(&httpvalidator.Route{Body: mustLoad(t, `{"required": ["name"]}`)},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	type Case struct {
+		Message        string
+		AcceptLanguage string
+		Expected       string
+	}
+	cases := []Case{
+		{"Japanese", "ja-JP,ja;q=0.9", "プロパティ 'name' は必須です"},
+		{"English", "en-US", "the property 'name' is required"},
+		{"no header", "", "the property 'name' is required"},
+	}
+	for _, c := range cases {
+		r := httptest.NewRequest("POST", "/users", strings.NewReader(`{}`))
+		r.Header.Set("Content-Type", "application/json")
+		if c.AcceptLanguage != "" {
+			r.Header.Set("Accept-Language", c.AcceptLanguage)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		var res struct {
+			Errors []*httpvalidator.Error `json:"errors"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Errors) != 1 || res.Errors[0].Message != c.Expected {
+			t.Errorf("Test with %s: expected %q, but actual %s", c.Message, c.Expected, w.Body.String())
+		}
+	}
+}
+
+func TestHandlerWithMaxBodyBytes(t *testing.T) {
+	v := httpvalidator.New()
+	v.MaxBodyBytes = 16
+	handler := v.Handler(&httpvalidator.Route{Body: mustLoad(t, `{"type": "object"}`)},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	type Case struct {
+		Message string
+		Body    string
+		Status  int
+	}
+	cases := []Case{
+		{"body within the limit", `{"a": "1234567"}`, http.StatusOK},
+		{"body over the limit", `{"a": "12345678"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("POST", "/users", strings.NewReader(c.Body)))
+		if w.Code != c.Status {
+			t.Errorf("Test with %s: expected status %d, but actual %d", c.Message, c.Status, w.Code)
+		}
+	}
+}
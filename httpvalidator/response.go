@@ -0,0 +1,95 @@
+package httpvalidator
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+)
+
+// ValidateResponse validates the JSON response body against the schema of
+// route for the status code. The empty body and the status codes without
+// the schema are not validated.
+func ValidateResponse(route *Route, status int, body []byte) []*Error {
+	return ValidateResponseWith(messages.English, route, status, body)
+}
+
+// ValidateResponseWith is ValidateResponse with the messages of the errors
+// in the catalog c.
+func ValidateResponseWith(c *messages.Catalog, route *Route, status int, body []byte) []*Error {
+	s, ok := route.Responses[status]
+	if !ok {
+		s, ok = route.Responses[0]
+	}
+	if !ok || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	v, err := decodeJSON(body)
+	if err != nil {
+		return []*Error{{In: InResponse, Message: err.Error()}}
+	}
+	return convertErrors(c, InResponse, s.Validate(v))
+}
+
+// TestingT is the part of testing.TB which AssertResponse uses.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertResponse validates res against route like ValidateResponse, and
+// reports the errors to t. It returns whether res is valid. The body of
+// res is restored, so that it can be read again.
+//
+//	w := httptest.NewRecorder()
+//	handler.ServeHTTP(w, r)
+//	httpvalidator.AssertResponse(t, route, w.Result())
+func AssertResponse(t TestingT, route *Route, res *http.Response) bool {
+	t.Helper()
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		t.Errorf("fail to read the response body: %s", err)
+		return false
+	}
+	errs := ValidateResponse(route, res.StatusCode, body)
+	for _, e := range errs {
+		path := e.InstancePath
+		if path == "" {
+			path = "(root)"
+		}
+		t.Errorf("invalid response %d: %s: %s", res.StatusCode, path, e.Message)
+	}
+	return len(errs) == 0
+}
+
+// responseRecorder captures the status code and the body of the response
+// which it writes to ResponseWriter.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
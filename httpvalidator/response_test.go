@@ -0,0 +1,129 @@
+package httpvalidator_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/httpvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+// recordingT records the errors instead of failing the test.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertResponse(t *testing.T) {
+	route := &httpvalidator.Route{
+		Responses: map[int]*schema.Schema{
+			http.StatusOK: mustLoad(t, `{"type": "object", "required": ["id"]}`),
+			0:             mustLoad(t, `{"type": "object", "required": ["message"]}`),
+		},
+	}
+
+	type Case struct {
+		Message string
+		Status  int
+		Body    string
+		Errors  []string
+	}
+	cases := []Case{
+		{
+			Message: "valid response",
+			Status:  http.StatusOK,
+			Body:    `{"id": 1}`,
+			Errors:  nil,
+		},
+		{
+			Message: "invalid response",
+			Status:  http.StatusOK,
+			Body:    `{"name": "Alice"}`,
+			Errors:  []string{"invalid response 200: (root): the property 'id' is required"},
+		},
+		{
+			Message: "default schema",
+			Status:  http.StatusNotFound,
+			Body:    `{}`,
+			Errors:  []string{"invalid response 404: (root): the property 'message' is required"},
+		},
+		{
+			Message: "empty body",
+			Status:  http.StatusNoContent,
+			Errors:  nil,
+		},
+		{
+			Message: "malformed body",
+			Status:  http.StatusOK,
+			Body:    `{`,
+			Errors:  []string{"invalid response 200: (root): the body is not a valid JSON"},
+		},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		w.WriteHeader(c.Status)
+		w.WriteString(c.Body)
+		res := w.Result()
+
+		rt := &recordingT{}
+		valid := httpvalidator.AssertResponse(rt, route, res)
+		if !reflect.DeepEqual(rt.errors, c.Errors) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Errors, rt.errors)
+		}
+		if valid != (c.Errors == nil) {
+			t.Errorf("%s: expected %v, but actual %v", c.Message, c.Errors == nil, valid)
+		}
+		if body, _ := ioutil.ReadAll(res.Body); string(body) != c.Body {
+			t.Errorf("%s: expected the restored body %q, but actual %q", c.Message, c.Body, body)
+		}
+	}
+}
+
+func TestMiddlewareWithResponseSampling(t *testing.T) {
+	v := httpvalidator.New()
+	v.ResponseSampleRate = 1
+	v.Messages = httpvalidator.AcceptLanguage
+	var reported []*httpvalidator.Error
+	v.ResponseErrorHandler = func(r *http.Request, status int, errs []*httpvalidator.Error) {
+		reported = append(reported, errs...)
+	}
+	v.Route("GET", "/users", &httpvalidator.Route{
+		Responses: map[int]*schema.Schema{
+			http.StatusOK: mustLoad(t, `{"type": "array", "items": {"type": "string"}}`),
+		},
+	})
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["alice", 1]`))
+	}))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/users", strings.NewReader(""))
+	r.Header.Set("Accept-Language", "ja")
+	handler.ServeHTTP(w, r)
+	if w.Body.String() != `["alice", 1]` {
+		t.Errorf("expected the response to be sent, but actual %q", w.Body.String())
+	}
+	if len(reported) != 1 || reported[0].InstancePath != "/1" || reported[0].In != httpvalidator.InResponse {
+		t.Errorf("expected the error of /1, but actual %v", reported)
+	} else if expected := "値 1 の型は [string] である必要があります"; reported[0].Message != expected {
+		t.Errorf("expected the message %q of Messages, but actual %q", expected, reported[0].Message)
+	}
+
+	reported = nil
+	v.ResponseSampleRate = 0
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users", strings.NewReader("")))
+	if reported != nil {
+		t.Errorf("expected no validation without sampling, but actual %v", reported)
+	}
+}
@@ -1,5 +1,14 @@
 package integers
 
-type Validator interface {
-	Validate(input int) error
-}
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates an integer.
+type Validator = validators.Validator[int]
+
+var (
+	_ Validator = EnumValidator{}
+	_ Validator = MaximumValidator{}
+	_ Validator = MinimumValidator{}
+)
+
+var _ validators.Validator[interface{}] = ValueValidator{}
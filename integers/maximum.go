@@ -18,9 +18,9 @@ type MaximumValidationError struct {
 
 func (err MaximumValidationError) Error() string {
 	if err.Definition.Exclusive {
-		return fmt.Sprintf("the value %f should be less than %f", err.Input, err.Definition.Maximum)
+		return fmt.Sprintf("the value %d should be less than %d", err.Input, err.Definition.Maximum)
 	}
-	return fmt.Sprintf("the value %f should be less than or equal to %f", err.Input, err.Definition.Maximum)
+	return fmt.Sprintf("the value %d should be less than or equal to %d", err.Input, err.Definition.Maximum)
 }
 
 func NewMaximumValidator(definition MaximumValidatorDefinition) (MaximumValidator, error) {
@@ -14,9 +14,9 @@ type MinimumValidationError struct {
 
 func (err MinimumValidationError) Error() string {
 	if err.Definition.Exclusive {
-		return fmt.Sprintf("the value %f should be greater than %f", err.Input, err.Definition.Minimum)
+		return fmt.Sprintf("the value %d should be greater than %d", err.Input, err.Definition.Minimum)
 	}
-	return fmt.Sprintf("the value %f should be greater than or equal to %f", err.Input, err.Definition.Minimum)
+	return fmt.Sprintf("the value %d should be greater than or equal to %d", err.Input, err.Definition.Minimum)
 }
 
 type MinimumValidator struct {
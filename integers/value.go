@@ -0,0 +1,88 @@
+package integers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/go-jstmpl/go-jsvalidator/internal/nullable"
+)
+
+var ValueDefinitionNoValidatorError = errors.New("the Validator should not be nil")
+
+// ValueValidator validates the values which hold an integer, such as *int,
+// sql.NullInt64, dbr.NullInt64 and the other driver.Valuer, with Validator.
+type ValueValidator struct {
+	definition ValueValidatorDefinition
+}
+
+type ValueValidatorDefinition struct {
+	Validator Validator `json:"-"`
+	// Nullable reports whether nil, the nil pointers and NULL are valid.
+	// They are NullValidationError otherwise.
+	Nullable bool `json:"nullable"`
+}
+
+type NullValidationError struct {
+	Definition ValueValidatorDefinition `json:"definition"`
+	Input      interface{}              `json:"input"`
+}
+
+func (err NullValidationError) Error() string {
+	return "the value should not be null"
+}
+
+// ValueTypeError for the values which don't hold an integer
+type ValueTypeError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err ValueTypeError) Error() string {
+	return fmt.Sprintf("%T doesn't hold an integer", err.Input)
+}
+
+func NewValueValidator(definition ValueValidatorDefinition) (ValueValidator, error) {
+	if definition.Validator == nil {
+		return ValueValidator{}, ValueDefinitionNoValidatorError
+	}
+	return ValueValidator{definition}, nil
+}
+
+func (v ValueValidator) Validate(input interface{}) error {
+	value, null, err := nullable.Unwrap(input)
+	if err != nil {
+		return err
+	}
+	if null {
+		if v.definition.Nullable {
+			return nil
+		}
+		return &NullValidationError{v.definition, input}
+	}
+	i, ok := toInt(value)
+	if !ok {
+		return &ValueTypeError{input}
+	}
+	return v.definition.Validator.Validate(i)
+}
+
+// toInt returns the int of value, which is an integer of any width that fits in int.
+func toInt(value interface{}) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if i < math.MinInt || i > math.MaxInt {
+			return 0, false
+		}
+		return int(i), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > math.MaxInt {
+			return 0, false
+		}
+		return int(u), true
+	}
+	return 0, false
+}
@@ -0,0 +1,107 @@
+package integers_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/gocraft/dbr"
+)
+
+func TestNewValueValidator(t *testing.T) {
+	if _, err := integers.NewValueValidator(integers.ValueValidatorDefinition{}); err != integers.ValueDefinitionNoValidatorError {
+		t.Errorf("expected %v, but actual %v", integers.ValueDefinitionNoValidatorError, err)
+	}
+}
+
+func TestValidateOfValueValidator(t *testing.T) {
+	maximum, err := integers.NewMaximumValidator(integers.MaximumValidatorDefinition{Maximum: 10})
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+	nullable := integers.ValueValidatorDefinition{Validator: maximum, Nullable: true}
+	notNullable := integers.ValueValidatorDefinition{Validator: maximum}
+	ten, eleven := int64(10), uint8(11)
+
+	type Case struct {
+		Message    string
+		Definition integers.ValueValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "valid int",
+			Definition: notNullable,
+			Input:      10,
+			Error:      nil,
+		},
+		{
+			Message:    "valid pointer",
+			Definition: notNullable,
+			Input:      &ten,
+			Error:      nil,
+		},
+		{
+			Message:    "invalid pointer",
+			Definition: notNullable,
+			Input:      &eleven,
+			Error: &integers.MaximumValidationError{
+				Definition: integers.MaximumValidatorDefinition{Maximum: 10},
+				Input:      11,
+			},
+		},
+		{
+			Message:    "valid sql.NullInt64",
+			Definition: notNullable,
+			Input:      sql.NullInt64{Int64: 10, Valid: true},
+			Error:      nil,
+		},
+		{
+			Message:    "invalid dbr.NullInt64",
+			Definition: notNullable,
+			Input:      dbr.NewNullInt64(11),
+			Error: &integers.MaximumValidationError{
+				Definition: integers.MaximumValidatorDefinition{Maximum: 10},
+				Input:      11,
+			},
+		},
+		{
+			Message:    "NULL with nullable",
+			Definition: nullable,
+			Input:      dbr.NewNullInt64(nil),
+			Error:      nil,
+		},
+		{
+			Message:    "nil with nullable",
+			Definition: nullable,
+			Input:      nil,
+			Error:      nil,
+		},
+		{
+			Message:    "NULL without nullable",
+			Definition: notNullable,
+			Input:      sql.NullInt64{},
+			Error: &integers.NullValidationError{
+				Definition: notNullable,
+				Input:      sql.NullInt64{},
+			},
+		},
+		{
+			Message:    "wrong type",
+			Definition: notNullable,
+			Input:      sql.NullString{String: "10", Valid: true},
+			Error:      &integers.ValueTypeError{Input: sql.NullString{String: "10", Valid: true}},
+		},
+	}
+	for _, c := range cases {
+		v, err := integers.NewValueValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package nullable unwraps the values of pointers and driver.Valuer such as
+// sql.NullString and dbr.NullString for the validators.
+package nullable
+
+import (
+	"database/sql/driver"
+	"reflect"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// Unwrap returns the value which input holds: the value which the pointers
+// point to, or the driver.Value of the driver.Valuer. The null reports
+// whether input is nil, a nil pointer or NULL.
+func Unwrap(input interface{}) (value interface{}, null bool, err error) {
+	v := reflect.ValueOf(input)
+	for {
+		if !v.IsValid() {
+			return nil, true, nil
+		}
+		if v.Type().Implements(valuerType) {
+			if v.Kind() == reflect.Ptr && v.IsNil() {
+				return nil, true, nil
+			}
+			value, err := v.Interface().(driver.Valuer).Value()
+			if err != nil {
+				return nil, false, err
+			}
+			return value, value == nil, nil
+		}
+		if v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface {
+			return v.Interface(), false, nil
+		}
+		if v.IsNil() {
+			return nil, true, nil
+		}
+		v = v.Elem()
+	}
+}
@@ -0,0 +1,140 @@
+package messages
+
+// English is the built-in catalog of "en", whose messages are the same as the
+// Error of the errors.
+var English = mustNew("en", map[string]string{
+	"schema.FalseSchemaValidationError":          `any value is not allowed`,
+	"schema.TypeValidationError":                 `input value {{printf "%v" .Input}} should be {{.Types}}`,
+	"schema.EnumValidationError":                 `input value {{printf "%v" .Input}} doesn't exist in {{printf "%v" .Enum}}`,
+	"schema.ConstValidationError":                `input value {{printf "%v" .Input}} should be {{printf "%v" .Const}}`,
+	"schema.MultipleOfValidationError":           `the value {{printf "%v" .Input}} should be a multiple of {{.MultipleOf}}`,
+	"schema.UniqueItemsValidationError":          `the items of {{printf "%v" .Input}} should be unique`,
+	"schema.ContainsValidationError":             `{{if and .MaxContains (gt .Matched (deref .MaxContains))}}should contain at most {{deref .MaxContains}} matching items but actual {{.Matched}}{{else}}should contain at least {{.MinContains}} matching items but actual {{.Matched}}{{end}}`,
+	"schema.RequiredValidationError":             `the property '{{.Property}}' is required`,
+	"schema.DependentRequiredValidationError":    `the property '{{.Property}}' is required when '{{.Dependent}}' is present`,
+	"schema.AdditionalPropertiesValidationError": `the property '{{.Property}}' is not allowed`,
+	"schema.MaxPropertiesValidationError":        `should have less than, or equal to, {{.MaxProperties}} properties but actual value has {{.Properties}} properties`,
+	"schema.MinPropertiesValidationError":        `should have greater than, or equal to, {{.MinProperties}} properties but actual value has {{.Properties}} properties`,
+	"schema.AnyOfValidationError":                `input value doesn't match any of the schemas`,
+	"schema.OneOfValidationError":                `{{if eq .Matched 0}}input value doesn't match any of the schemas{{else}}input value should match exactly one schema but matches {{.Matched}} schemas{{end}}`,
+	"schema.DiscriminatorValidationError":        `the property '{{.PropertyName}}' should be one of {{.Values}} to select the schema, but actual {{printf "%v" .Input}}`,
+	"schema.NotValidationError":                  `input value {{printf "%v" .Input}} should not match the schema`,
+	"schema.CoercionValidationError":             `input value '{{.Input}}' can't be converted to {{.Types}}`,
+	"schema.LimitExceededError":                  `the validation exceeds the {{.Limit}} limit {{.Max}} at {{if .InstancePath}}{{.InstancePath}}{{else}}(root){{end}}`,
+
+	"strings.MaxLengthValidationError": `should be less than, or equal to, {{.Definition.MaxLength}} characters but actual value has {{runes .Input}} characters`,
+	"strings.MinLengthValidationError": `should be greater than, or equal to, {{.Definition.MinLength}} characters but actual value has {{runes .Input}} characters`,
+	"strings.PatternValidationError":   `input value '{{.Input}}' does not match the regex pattern '{{.Definition.Pattern}}'`,
+	"strings.FormatValidationError":    `input value '{{.Input}}' does not match the pattern for '{{.Definition.Format}}'`,
+	"strings.EnumValidationError":      `input value '{{.Input}}' doesn't exist in {{.Definition.Enum}}`,
+	"strings.NullValidationError":      `the value should not be null`,
+	"strings.ValueTypeError":           `{{printf "%T" .Input}} doesn't hold a string`,
+
+	"integers.MaximumValidationError": `the value {{.Input}} should be less than {{if not .Definition.Exclusive}}or equal to {{end}}{{.Definition.Maximum}}`,
+	"integers.MinimumValidationError": `the value {{.Input}} should be greater than {{if not .Definition.Exclusive}}or equal to {{end}}{{.Definition.Minimum}}`,
+	"integers.EnumValidationError":    `input value {{.Input}} doesn't exist in {{.Definition.Enum}}`,
+	"integers.NullValidationError":    `the value should not be null`,
+	"integers.ValueTypeError":         `{{printf "%T" .Input}} doesn't hold an integer`,
+
+	"numbers.MaximumValidationError": `the value {{printf "%f" .Input}} should be less than {{if not .Definition.Exclusive}}or equal to {{end}}{{printf "%f" .Definition.Maximum}}`,
+	"numbers.MinimumValidationError": `the value {{printf "%f" .Input}} should be greater than {{if not .Definition.Exclusive}}or equal to {{end}}{{printf "%f" .Definition.Minimum}}`,
+	"numbers.EnumValidationError":    `input value {{printf "%f" .Input}} doesn't exist in {{.Definition.Enum}}`,
+	"numbers.NullValidationError":    `the value should not be null`,
+	"numbers.ValueTypeError":         `{{printf "%T" .Input}} doesn't hold a number`,
+
+	"bignumbers.MaximumValidationError":    `the value {{printf "%v" .Input}} should be less than {{if not .Definition.Exclusive}}or equal to {{end}}{{.Definition.Maximum}}`,
+	"bignumbers.MinimumValidationError":    `the value {{printf "%v" .Input}} should be greater than {{if not .Definition.Exclusive}}or equal to {{end}}{{.Definition.Minimum}}`,
+	"bignumbers.MultipleOfValidationError": `the value {{printf "%v" .Input}} should be a multiple of {{.Definition.MultipleOf}}`,
+	"bignumbers.EnumValidationError":       `input value {{printf "%v" .Input}} doesn't exist in {{.Definition.Enum}}`,
+	"bignumbers.ConstValidationError":      `input value {{printf "%v" .Input}} should be {{.Definition.Const}}`,
+	"bignumbers.TypeError":                 `{{printf "%T" .Input}} isn't a number`,
+
+	"booleans.EnumValidationError": `input value {{.Input}} doesn't exist in {{.Definition.Enum}}`,
+	"booleans.NullValidationError": `the value should not be null`,
+	"booleans.ValueTypeError":      `{{printf "%T" .Input}} doesn't hold a boolean`,
+
+	"arrays.MaxItemsValidationError": `the length of {{printf "%v" .Input}} should be less than or equal to {{.Definition.MaxItems}}`,
+	"arrays.MinItemsValidationError": `the length of {{printf "%v" .Input}} should be greater than or equal to {{.Definition.MinItems}}`,
+	"arrays.TypeError":               `{{.Message}}`,
+
+	"validators.AnyValidationError": `input value doesn't match any of the validators`,
+	"validators.NotValidationError": `input value {{printf "%v" .Input}} should not match the validator`,
+	"validators.TypeError":          `{{printf "%T" .Input}} should be {{.Type}}`,
+
+	"validator.RequiredValidationError": `input struct does not satisfy required values '{{.Definition.Required}}'`,
+	"validator.InvalidFieldTypeError":   `input struct have invalid field against required '{{.Definition.Required}}'`,
+	"validator.InvalidTypeError":        `the type of argument for the Validate of the RequiredValidator should be struct or pointer struct ` + "`{{.Definition.Required}}`",
+})
+
+// Japanese is the built-in catalog of "ja".
+var Japanese = mustNew("ja", map[string]string{
+	"schema.FalseSchemaValidationError":          `値は許可されていません`,
+	"schema.TypeValidationError":                 `値 {{printf "%v" .Input}} の型は {{.Types}} である必要があります`,
+	"schema.EnumValidationError":                 `値 {{printf "%v" .Input}} は {{printf "%v" .Enum}} のいずれかである必要があります`,
+	"schema.ConstValidationError":                `値 {{printf "%v" .Input}} は {{printf "%v" .Const}} である必要があります`,
+	"schema.MultipleOfValidationError":           `値 {{printf "%v" .Input}} は {{.MultipleOf}} の倍数である必要があります`,
+	"schema.UniqueItemsValidationError":          `{{printf "%v" .Input}} の要素は重複しないようにしてください`,
+	"schema.ContainsValidationError":             `{{if and .MaxContains (gt .Matched (deref .MaxContains))}}条件に一致する要素は {{deref .MaxContains}} 個以下である必要がありますが、{{.Matched}} 個あります{{else}}条件に一致する要素は {{.MinContains}} 個以上である必要がありますが、{{.Matched}} 個です{{end}}`,
+	"schema.RequiredValidationError":             `プロパティ '{{.Property}}' は必須です`,
+	"schema.DependentRequiredValidationError":    `'{{.Dependent}}' がある場合、プロパティ '{{.Property}}' は必須です`,
+	"schema.AdditionalPropertiesValidationError": `プロパティ '{{.Property}}' は許可されていません`,
+	"schema.MaxPropertiesValidationError":        `プロパティは {{.MaxProperties}} 個以下である必要がありますが、{{.Properties}} 個あります`,
+	"schema.MinPropertiesValidationError":        `プロパティは {{.MinProperties}} 個以上である必要がありますが、{{.Properties}} 個です`,
+	"schema.AnyOfValidationError":                `値はいずれのスキーマにも一致しません`,
+	"schema.OneOfValidationError":                `{{if eq .Matched 0}}値はいずれのスキーマにも一致しません{{else}}値はちょうど 1 つのスキーマに一致する必要がありますが、{{.Matched}} 個のスキーマに一致します{{end}}`,
+	"schema.DiscriminatorValidationError":        `スキーマを選択するプロパティ '{{.PropertyName}}' は {{.Values}} のいずれかである必要がありますが、{{printf "%v" .Input}} です`,
+	"schema.NotValidationError":                  `値 {{printf "%v" .Input}} はスキーマに一致しないようにしてください`,
+	"schema.CoercionValidationError":             `値 '{{.Input}}' は {{.Types}} に変換できません`,
+	"schema.LimitExceededError":                  `{{if .InstancePath}}{{.InstancePath}}{{else}}(root){{end}} で検証の {{.Limit}} の上限 {{.Max}} を超えました`,
+
+	"strings.MaxLengthValidationError": `{{.Definition.MaxLength}} 文字以下である必要がありますが、{{runes .Input}} 文字あります`,
+	"strings.MinLengthValidationError": `{{.Definition.MinLength}} 文字以上である必要がありますが、{{runes .Input}} 文字です`,
+	"strings.PatternValidationError":   `値 '{{.Input}}' は正規表現 '{{.Definition.Pattern}}' に一致しません`,
+	"strings.FormatValidationError":    `値 '{{.Input}}' は '{{.Definition.Format}}' の形式ではありません`,
+	"strings.EnumValidationError":      `値 '{{.Input}}' は {{.Definition.Enum}} のいずれかである必要があります`,
+	"strings.NullValidationError":      `値は null にできません`,
+	"strings.ValueTypeError":           `{{printf "%T" .Input}} は文字列を保持していません`,
+
+	"integers.MaximumValidationError": `値 {{.Input}} は {{.Definition.Maximum}} {{if .Definition.Exclusive}}未満{{else}}以下{{end}}である必要があります`,
+	"integers.MinimumValidationError": `値 {{.Input}} は {{.Definition.Minimum}} {{if .Definition.Exclusive}}より大きい値{{else}}以上{{end}}である必要があります`,
+	"integers.EnumValidationError":    `値 {{.Input}} は {{.Definition.Enum}} のいずれかである必要があります`,
+	"integers.NullValidationError":    `値は null にできません`,
+	"integers.ValueTypeError":         `{{printf "%T" .Input}} は整数を保持していません`,
+
+	"numbers.MaximumValidationError": `値 {{.Input}} は {{.Definition.Maximum}} {{if .Definition.Exclusive}}未満{{else}}以下{{end}}である必要があります`,
+	"numbers.MinimumValidationError": `値 {{.Input}} は {{.Definition.Minimum}} {{if .Definition.Exclusive}}より大きい値{{else}}以上{{end}}である必要があります`,
+	"numbers.EnumValidationError":    `値 {{.Input}} は {{.Definition.Enum}} のいずれかである必要があります`,
+	"numbers.NullValidationError":    `値は null にできません`,
+	"numbers.ValueTypeError":         `{{printf "%T" .Input}} は数値を保持していません`,
+
+	"bignumbers.MaximumValidationError":    `値 {{printf "%v" .Input}} は {{.Definition.Maximum}} {{if .Definition.Exclusive}}未満{{else}}以下{{end}}である必要があります`,
+	"bignumbers.MinimumValidationError":    `値 {{printf "%v" .Input}} は {{.Definition.Minimum}} {{if .Definition.Exclusive}}より大きい値{{else}}以上{{end}}である必要があります`,
+	"bignumbers.MultipleOfValidationError": `値 {{printf "%v" .Input}} は {{.Definition.MultipleOf}} の倍数である必要があります`,
+	"bignumbers.EnumValidationError":       `値 {{printf "%v" .Input}} は {{.Definition.Enum}} のいずれかである必要があります`,
+	"bignumbers.ConstValidationError":      `値 {{printf "%v" .Input}} は {{.Definition.Const}} である必要があります`,
+	"bignumbers.TypeError":                 `{{printf "%T" .Input}} は数値ではありません`,
+
+	"booleans.EnumValidationError": `値 {{.Input}} は {{.Definition.Enum}} のいずれかである必要があります`,
+	"booleans.NullValidationError": `値は null にできません`,
+	"booleans.ValueTypeError":      `{{printf "%T" .Input}} は真偽値を保持していません`,
+
+	"arrays.MaxItemsValidationError": `要素は {{.Definition.MaxItems}} 個以下である必要があります`,
+	"arrays.MinItemsValidationError": `要素は {{.Definition.MinItems}} 個以上である必要があります`,
+	"arrays.TypeError":               `値は配列である必要があります`,
+
+	"validators.AnyValidationError": `値はいずれのバリデータにも一致しません`,
+	"validators.NotValidationError": `値 {{printf "%v" .Input}} はバリデータに一致しないようにしてください`,
+	"validators.TypeError":          `{{printf "%T" .Input}} は {{.Type}} である必要があります`,
+
+	"validator.RequiredValidationError": `必須の値 '{{.Definition.Required}}' がありません`,
+	"validator.InvalidFieldTypeError":   `構造体に必須の値 '{{.Definition.Required}}' として不正な型のフィールドがあります`,
+	"validator.InvalidTypeError":        `RequiredValidator の Validate の引数は構造体または構造体のポインタである必要があります '{{.Definition.Required}}'`,
+})
+
+func mustNew(locale string, templates map[string]string) *Catalog {
+	c, err := New(locale, templates)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
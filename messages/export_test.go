@@ -0,0 +1,7 @@
+package messages
+
+// HasTemplate reports whether c or the catalogs it overrides have the
+// template of key.
+func (c *Catalog) HasTemplate(key string) bool {
+	return c.template(key) != nil
+}
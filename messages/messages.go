@@ -0,0 +1,203 @@
+// Package messages renders the validation errors in the languages of message
+// catalogs, leaving the structured fields of the errors unchanged.
+package messages
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+	"unicode/utf8"
+)
+
+// Catalog is the message templates of a locale.
+//
+// The templates are text/template executed with the error, and are keyed by
+// the type of the error such as "strings.MaxLengthValidationError", or by the
+// keyword and the type such as "format strings.FormatValidationError", which
+// takes precedence. The templates which are not in the catalog are looked up
+// in the catalog it overrides, and the message is the Error of the error when
+// there is no template.
+type Catalog struct {
+	Locale    string
+	templates map[string]*template.Template
+	parent    *Catalog
+}
+
+var funcs = template.FuncMap{
+	// runes returns the number of the characters of s.
+	"runes": utf8.RuneCountInString,
+	// deref returns the value of the pointer p.
+	"deref": func(p *int) int { return *p },
+}
+
+// New returns the catalog of the locale with the templates.
+func New(locale string, templates map[string]string) (*Catalog, error) {
+	return newCatalog(locale, templates, nil)
+}
+
+func newCatalog(locale string, templates map[string]string, parent *Catalog) (*Catalog, error) {
+	c := &Catalog{Locale: locale, templates: make(map[string]*template.Template, len(templates)), parent: parent}
+	for key, text := range templates {
+		t, err := Parse(key, text)
+		if err != nil {
+			return nil, err
+		}
+		c.templates[key] = t
+	}
+	return c, nil
+}
+
+// Parse parses a message template with the functions of the catalogs, such
+// as runes which counts the characters of a string.
+func Parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(funcs).Parse(text)
+}
+
+// Override returns the catalog whose templates are replaced with templates.
+// c is not modified.
+func (c *Catalog) Override(templates map[string]string) (*Catalog, error) {
+	return newCatalog(c.Locale, templates, c)
+}
+
+// Message returns the message of err reported for the keyword.
+func (c *Catalog) Message(keyword string, err error) string {
+	if err == nil {
+		return ""
+	}
+	name := Key(err)
+	t := c.template(keyword + " " + name)
+	if t == nil {
+		t = c.template(name)
+	}
+	if t == nil {
+		return err.Error()
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, err); err != nil {
+		return err.Error()
+	}
+	return b.String()
+}
+
+func (c *Catalog) template(key string) *template.Template {
+	for ; c != nil; c = c.parent {
+		if t, ok := c.templates[key]; ok {
+			return t
+		}
+	}
+	return nil
+}
+
+// Key returns the name of the type of err by which the templates are keyed,
+// such as "schema.RequiredValidationError" for *schema.RequiredValidationError.
+func Key(err error) string {
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]*Catalog{
+		English.Locale:  English,
+		Japanese.Locale: Japanese,
+	}
+)
+
+// Register makes c the catalog of its locale returned by Lookup and
+// Negotiate, replacing the built-in one.
+func Register(c *Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	catalogs[strings.ToLower(c.Locale)] = c
+}
+
+// Lookup returns the catalog of the locale such as "ja" or "ja-JP", which
+// falls back to the language of the locale, and to English.
+func Lookup(locale string) *Catalog {
+	if c, ok := lookup(locale); ok {
+		return c
+	}
+	return English
+}
+
+func lookup(locale string) (*Catalog, bool) {
+	locale = strings.ToLower(strings.Replace(strings.TrimSpace(locale), "_", "-", -1))
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	if c, ok := catalogs[locale]; ok {
+		return c, true
+	}
+	if i := strings.IndexByte(locale, '-'); i >= 0 {
+		c, ok := catalogs[locale[:i]]
+		return c, ok
+	}
+	return nil, false
+}
+
+// Negotiate returns the catalog of the first locale in the value of an
+// Accept-Language header which has a catalog, or English. The quality values
+// are honored, and the languages of the same quality are in the order of the
+// header.
+func Negotiate(acceptLanguage string) *Catalog {
+	var best *Catalog
+	bestQ := 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		locale, q := parseLanguage(part)
+		if q <= bestQ {
+			continue
+		}
+		if c, ok := lookup(locale); ok {
+			best, bestQ = c, q
+		}
+	}
+	if best == nil {
+		return English
+	}
+	return best
+}
+
+// parseLanguage returns the language range and the quality of an element of
+// Accept-Language such as "ja;q=0.8".
+func parseLanguage(s string) (string, float64) {
+	params := strings.Split(s, ";")
+	q := 1.0
+	for _, param := range params[1:] {
+		param = strings.TrimSpace(param)
+		if !strings.HasPrefix(param, "q=") {
+			continue
+		}
+		q = parseQuality(param[2:])
+	}
+	return strings.TrimSpace(params[0]), q
+}
+
+// parseQuality parses the qvalue of RFC 7231, which is 0 when it is invalid.
+func parseQuality(s string) float64 {
+	if s == "" || len(s) > 5 || (s[0] != '0' && s[0] != '1') {
+		return 0
+	}
+	q := float64(s[0] - '0')
+	if len(s) == 1 {
+		return q
+	}
+	if s[1] != '.' {
+		return 0
+	}
+	scale := 0.1
+	for _, c := range s[2:] {
+		if c < '0' || c > '9' {
+			return 0
+		}
+		q += float64(c-'0') * scale
+		scale /= 10
+	}
+	if q > 1 {
+		return 0
+	}
+	return q
+}
@@ -0,0 +1,325 @@
+package messages_test
+
+import (
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	stdstrings "strings"
+	"testing"
+
+	validator "github.com/go-jstmpl/go-jsvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/booleans"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+	"github.com/go-jstmpl/go-jsvalidator/validators"
+)
+
+var two = 2
+
+var validationErrors = []error{
+	&schema.FalseSchemaValidationError{Input: 1},
+	&schema.TypeValidationError{Types: []string{"string"}, Input: nil},
+	&schema.EnumValidationError{Enum: []interface{}{"a", 1.0}, Input: true},
+	&schema.ConstValidationError{Const: "a", Input: "b"},
+	&schema.MultipleOfValidationError{MultipleOf: 0.5, Input: 0.7},
+	&schema.UniqueItemsValidationError{Input: []interface{}{1.0, 1.0}},
+	&schema.ContainsValidationError{MinContains: 1, Matched: 0},
+	&schema.ContainsValidationError{MinContains: 1, MaxContains: &two, Matched: 3},
+	&schema.RequiredValidationError{Required: []string{"a"}, Property: "a"},
+	&schema.DependentRequiredValidationError{Dependent: "a", Required: []string{"b"}, Property: "b"},
+	&schema.AdditionalPropertiesValidationError{Property: "a"},
+	&schema.MaxPropertiesValidationError{MaxProperties: 1, Properties: 2},
+	&schema.MinPropertiesValidationError{MinProperties: 2, Properties: 1},
+	&schema.AnyOfValidationError{},
+	&schema.OneOfValidationError{Matched: 0},
+	&schema.OneOfValidationError{Matched: 2},
+	&schema.DiscriminatorValidationError{PropertyName: "kind", Values: []string{"a", "b"}, Input: "c"},
+	&schema.NotValidationError{Input: 1.0},
+	&schema.CoercionValidationError{Types: []string{"integer"}, Input: "x"},
+	&schema.LimitExceededError{Limit: "depth", Max: 10, InstancePath: "/a"},
+	&schema.LimitExceededError{Limit: "nodes", Max: 10},
+	&strings.MaxLengthValidationError{Definition: strings.MaxLengthValidatorDefinition{MaxLength: 2}, Input: "あいう"},
+	&strings.MinLengthValidationError{Definition: strings.MinLengthValidatorDefinition{MinLength: 4}, Input: "あいう"},
+	&strings.PatternValidationError{Definition: strings.PatternValidatorDefinition{Pattern: "^a$"}, Input: "b"},
+	&strings.FormatValidationError{Definition: strings.FormatValidatorDefinition{Format: "email"}, Input: "b"},
+	&strings.EnumValidationError{Definition: strings.EnumValidatorDefinition{Enum: []string{"a"}}, Input: "b"},
+	&strings.ValueTypeError{Input: 1},
+	&strings.NullValidationError{},
+	&integers.MaximumValidationError{Definition: integers.MaximumValidatorDefinition{Maximum: 1, Exclusive: true}, Input: 1},
+	&integers.MinimumValidationError{Definition: integers.MinimumValidatorDefinition{Minimum: 1}, Input: 0},
+	&integers.EnumValidationError{Definition: integers.EnumValidatorDefinition{Enum: []int{1}}, Input: 2},
+	&integers.ValueTypeError{Input: "1"},
+	&integers.NullValidationError{},
+	&numbers.MaximumValidationError{Definition: numbers.MaximumValidatorDefinition{Maximum: 1.5}, Input: 2},
+	&numbers.MinimumValidationError{Definition: numbers.MinimumValidatorDefinition{Minimum: 1.5}, Input: 1},
+	&numbers.EnumValidationError{Definition: numbers.EnumValidatorDefinition{Enum: []float64{1.5}}, Input: 2},
+	&numbers.ValueTypeError{Input: "1"},
+	&numbers.NullValidationError{},
+	&bignumbers.MinimumValidationError{Definition: bignumbers.MinimumValidatorDefinition{Minimum: "1e30", Exclusive: true}, Input: "1e30"},
+	&bignumbers.MaximumValidationError{Definition: bignumbers.MaximumValidatorDefinition{Maximum: "1e30"}, Input: "2e30"},
+	&bignumbers.MultipleOfValidationError{Definition: bignumbers.MultipleOfValidatorDefinition{MultipleOf: "0.1"}, Input: 0.15},
+	&bignumbers.EnumValidationError{Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{"1"}}, Input: 2},
+	&bignumbers.ConstValidationError{Definition: bignumbers.ConstValidatorDefinition{Const: "1"}, Input: 2},
+	&bignumbers.TypeError{Input: "1"},
+	&booleans.EnumValidationError{Definition: booleans.EnumValidatorDefinition{Enum: []bool{true}}, Input: false},
+	&booleans.ValueTypeError{Input: 1},
+	&booleans.NullValidationError{},
+	&arrays.MaxItemsValidationError{Definition: arrays.MaxItemsValidatorDefinition{MaxItems: 1}, Input: []int{1, 2}},
+	&arrays.MinItemsValidationError{Definition: arrays.MinItemsValidatorDefinition{MinItems: 3}, Input: []int{1, 2}},
+	&arrays.TypeError{Message: "int should be slice"},
+	&validators.AnyValidationError{},
+	&validators.NotValidationError{Input: "a"},
+	&validators.TypeError{Input: 1, Type: "string"},
+	&validator.RequiredValidationError{Definition: validator.RequiredValidatorDefinition{Required: []string{"a"}}},
+	&validator.InvalidFieldTypeError{Definition: validator.RequiredValidatorDefinition{Required: []string{"a"}}},
+	&validator.InvalidTypeError{Definition: validator.RequiredValidatorDefinition{Required: []string{"a"}}},
+}
+
+// nonValidationErrors are the exported error types which report the
+// definitions, the documents or the lists of the errors, so the catalogs
+// don't have them.
+var nonValidationErrors = map[string]bool{
+	"schema.DefinitionError":            true,
+	"schema.DefinitionErrors":           true,
+	"schema.ValidationError":            true,
+	"schema.ValidationErrors":           true,
+	"schema.LoadError":                  true,
+	"schema.YAMLError":                  true,
+	"schema.MetaSchemaError":            true,
+	"schema.InvalidDefaultsTargetError": true,
+	"strings.InvalidPatternError":       true,
+	"validators.Errors":                 true,
+	"validator.TagDefinitionError":      true,
+	"validator.InvalidStructError":      true,
+	"validator.UnsupportedTypeError":    true,
+}
+
+// errorTypes returns the keys of the exported types of the packages in dirs
+// which have the Error method.
+func errorTypes(t *testing.T, dirs ...string) []string {
+	var keys []string
+	for _, dir := range dirs {
+		pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(fi os.FileInfo) bool {
+			return !stdstrings.HasSuffix(fi.Name(), "_test.go")
+		}, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, pkg := range pkgs {
+			for _, f := range pkg.Files {
+				for _, decl := range f.Decls {
+					fn, ok := decl.(*ast.FuncDecl)
+					if !ok || fn.Recv == nil || fn.Name.Name != "Error" {
+						continue
+					}
+					recv := fn.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if id, ok := recv.(*ast.Ident); ok && id.IsExported() {
+						keys = append(keys, name+"."+id.Name)
+					}
+				}
+			}
+		}
+	}
+	return keys
+}
+
+func TestCatalogsHaveErrors(t *testing.T) {
+	tested := map[string]bool{}
+	for _, err := range validationErrors {
+		tested[messages.Key(err)] = true
+	}
+	keys := errorTypes(t, "..", "../arrays", "../bignumbers", "../booleans", "../integers", "../numbers", "../schema", "../strings", "../validators")
+	for _, key := range keys {
+		if nonValidationErrors[key] {
+			continue
+		}
+		for _, c := range []*messages.Catalog{messages.English, messages.Japanese} {
+			if !c.HasTemplate(key) {
+				t.Errorf("expected the template of %s in %s", key, c.Locale)
+			}
+		}
+		if !tested[key] {
+			t.Errorf("expected %s in validationErrors", key)
+		}
+	}
+}
+
+func TestEnglish(t *testing.T) {
+	for _, err := range validationErrors {
+		// the Error of validator.RequiredValidationError ends with a newline
+		if actual, expected := messages.English.Message("", err), stdstrings.TrimSuffix(err.Error(), "\n"); actual != expected {
+			t.Errorf("Test with %s: expected %q, but actual %q", messages.Key(err), expected, actual)
+		}
+	}
+}
+
+func TestJapanese(t *testing.T) {
+	for _, err := range validationErrors {
+		if actual := messages.Japanese.Message("", err); actual == err.Error() || actual == "" {
+			t.Errorf("Test with %s: expected the message in Japanese, but actual %q", messages.Key(err), actual)
+		}
+	}
+
+	type Case struct {
+		Message  string
+		Err      error
+		Expected string
+	}
+	cases := []Case{
+		{
+			Message:  "maxLength",
+			Err:      &strings.MaxLengthValidationError{Definition: strings.MaxLengthValidatorDefinition{MaxLength: 2}, Input: "あいう"},
+			Expected: "2 文字以下である必要がありますが、3 文字あります",
+		},
+		{
+			Message:  "exclusive maximum",
+			Err:      &integers.MaximumValidationError{Definition: integers.MaximumValidatorDefinition{Maximum: 1, Exclusive: true}, Input: 1},
+			Expected: "値 1 は 1 未満である必要があります",
+		},
+		{
+			Message:  "required",
+			Err:      &schema.RequiredValidationError{Required: []string{"a"}, Property: "a"},
+			Expected: "プロパティ 'a' は必須です",
+		},
+		{
+			Message:  "error without template",
+			Err:      errors.New("unknown"),
+			Expected: "unknown",
+		},
+	}
+	for _, c := range cases {
+		if actual := messages.Japanese.Message("", c.Err); actual != c.Expected {
+			t.Errorf("Test with %s: expected %q, but actual %q", c.Message, c.Expected, actual)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	c, err := messages.Japanese.Override(map[string]string{
+		"strings.PatternValidationError":       "形式が正しくありません",
+		"format strings.FormatValidationError": "{{.Definition.Format}} の形式で入力してください",
+	})
+	if err != nil {
+		t.Fatalf("Fail to Override: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Keyword  string
+		Err      error
+		Expected string
+	}
+	cases := []Case{
+		{
+			Message:  "type",
+			Keyword:  "pattern",
+			Err:      &strings.PatternValidationError{Definition: strings.PatternValidatorDefinition{Pattern: "^a$"}, Input: "b"},
+			Expected: "形式が正しくありません",
+		},
+		{
+			Message:  "keyword",
+			Keyword:  "format",
+			Err:      &strings.FormatValidationError{Definition: strings.FormatValidatorDefinition{Format: "email"}, Input: "b"},
+			Expected: "email の形式で入力してください",
+		},
+		{
+			Message:  "other keyword",
+			Keyword:  "x-format",
+			Err:      &strings.FormatValidationError{Definition: strings.FormatValidatorDefinition{Format: "email"}, Input: "b"},
+			Expected: "値 'b' は 'email' の形式ではありません",
+		},
+		{
+			Message:  "parent",
+			Keyword:  "required",
+			Err:      &schema.RequiredValidationError{Property: "a"},
+			Expected: "プロパティ 'a' は必須です",
+		},
+	}
+	for _, cs := range cases {
+		if actual := c.Message(cs.Keyword, cs.Err); actual != cs.Expected {
+			t.Errorf("Test with %s: expected %q, but actual %q", cs.Message, cs.Expected, actual)
+		}
+	}
+
+	err = &strings.PatternValidationError{Definition: strings.PatternValidatorDefinition{Pattern: "^a$"}, Input: "b"}
+	if actual, expected := messages.Japanese.Message("pattern", err), "値 'b' は正規表現 '^a$' に一致しません"; actual != expected {
+		t.Errorf("expected the built-in catalog to be unchanged, but actual %q", actual)
+	}
+
+	if _, err := messages.English.Override(map[string]string{"schema.TypeValidationError": "{{"}); err == nil {
+		t.Errorf("expected the error of the invalid template")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	type Case struct {
+		Message  string
+		Locale   string
+		Expected *messages.Catalog
+	}
+	cases := []Case{
+		{"language", "ja", messages.Japanese},
+		{"region", "ja-JP", messages.Japanese},
+		{"underscore", "ja_JP", messages.Japanese},
+		{"English", "en-US", messages.English},
+		{"unknown", "fr", messages.English},
+		{"empty", "", messages.English},
+	}
+	for _, c := range cases {
+		if actual := messages.Lookup(c.Locale); actual != c.Expected {
+			t.Errorf("Test with %s: expected %s, but actual %s", c.Message, c.Expected.Locale, actual.Locale)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	type Case struct {
+		Message        string
+		AcceptLanguage string
+		Expected       *messages.Catalog
+	}
+	cases := []Case{
+		{"first", "ja-JP,ja;q=0.9,en;q=0.8", messages.Japanese},
+		{"quality", "en;q=0.5, ja;q=0.8", messages.Japanese},
+		{"unsupported", "fr, ja;q=0.1", messages.Japanese},
+		{"zero quality", "ja;q=0, en;q=0.5", messages.English},
+		{"invalid quality", "ja;q=2", messages.English},
+		{"empty", "", messages.English},
+	}
+	for _, c := range cases {
+		if actual := messages.Negotiate(c.AcceptLanguage); actual != c.Expected {
+			t.Errorf("Test with %s: expected %s, but actual %s", c.Message, c.Expected.Locale, actual.Locale)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	c, err := messages.New("eo", map[string]string{
+		"schema.RequiredValidationError": "la atributo '{{.Property}}' estas deviga",
+	})
+	if err != nil {
+		t.Fatalf("Fail to New: %s", err)
+	}
+	messages.Register(c)
+	if actual := messages.Lookup("eo-XX"); actual != c {
+		t.Errorf("expected the registered catalog, but actual %s", actual.Locale)
+	}
+	if actual := messages.Negotiate("eo;q=0.9, de"); actual != c {
+		t.Errorf("expected the registered catalog, but actual %s", actual.Locale)
+	}
+	err = &schema.AdditionalPropertiesValidationError{Property: "a"}
+	if actual, expected := c.Message("", err), err.Error(); actual != expected {
+		t.Errorf("expected %q, but actual %q", expected, actual)
+	}
+}
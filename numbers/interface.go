@@ -1,5 +1,14 @@
 package numbers
 
-type Validator interface {
-	Validate(input float64) error
-}
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates a number.
+type Validator = validators.Validator[float64]
+
+var (
+	_ Validator = EnumValidator{}
+	_ Validator = MaximumValidator{}
+	_ Validator = MinimumValidator{}
+)
+
+var _ validators.Validator[interface{}] = ValueValidator{}
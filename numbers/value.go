@@ -0,0 +1,82 @@
+package numbers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-jstmpl/go-jsvalidator/internal/nullable"
+)
+
+var ValueDefinitionNoValidatorError = errors.New("the Validator should not be nil")
+
+// ValueValidator validates the values which hold a number, such as *float64,
+// sql.NullFloat64, dbr.NullFloat64, sql.NullInt64 and the other driver.Valuer,
+// with Validator.
+type ValueValidator struct {
+	definition ValueValidatorDefinition
+}
+
+type ValueValidatorDefinition struct {
+	Validator Validator `json:"-"`
+	// Nullable reports whether nil, the nil pointers and NULL are valid.
+	// They are NullValidationError otherwise.
+	Nullable bool `json:"nullable"`
+}
+
+type NullValidationError struct {
+	Definition ValueValidatorDefinition `json:"definition"`
+	Input      interface{}              `json:"input"`
+}
+
+func (err NullValidationError) Error() string {
+	return "the value should not be null"
+}
+
+// ValueTypeError for the values which don't hold a number
+type ValueTypeError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err ValueTypeError) Error() string {
+	return fmt.Sprintf("%T doesn't hold a number", err.Input)
+}
+
+func NewValueValidator(definition ValueValidatorDefinition) (ValueValidator, error) {
+	if definition.Validator == nil {
+		return ValueValidator{}, ValueDefinitionNoValidatorError
+	}
+	return ValueValidator{definition}, nil
+}
+
+func (v ValueValidator) Validate(input interface{}) error {
+	value, null, err := nullable.Unwrap(input)
+	if err != nil {
+		return err
+	}
+	if null {
+		if v.definition.Nullable {
+			return nil
+		}
+		return &NullValidationError{v.definition, input}
+	}
+	f, ok := toFloat(value)
+	if !ok {
+		return &ValueTypeError{input}
+	}
+	return v.definition.Validator.Validate(f)
+}
+
+// toFloat returns the float64 of value, which is a number of any Go type.
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
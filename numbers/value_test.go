@@ -0,0 +1,107 @@
+package numbers_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/gocraft/dbr"
+)
+
+func TestNewValueValidator(t *testing.T) {
+	if _, err := numbers.NewValueValidator(numbers.ValueValidatorDefinition{}); err != numbers.ValueDefinitionNoValidatorError {
+		t.Errorf("expected %v, but actual %v", numbers.ValueDefinitionNoValidatorError, err)
+	}
+}
+
+func TestValidateOfValueValidator(t *testing.T) {
+	maximum, err := numbers.NewMaximumValidator(numbers.MaximumValidatorDefinition{Maximum: 1.5})
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+	nullable := numbers.ValueValidatorDefinition{Validator: maximum, Nullable: true}
+	notNullable := numbers.ValueValidatorDefinition{Validator: maximum}
+	less, greater := float32(1.5), 2.0
+
+	type Case struct {
+		Message    string
+		Definition numbers.ValueValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "valid float64",
+			Definition: notNullable,
+			Input:      1.5,
+			Error:      nil,
+		},
+		{
+			Message:    "valid pointer",
+			Definition: notNullable,
+			Input:      &less,
+			Error:      nil,
+		},
+		{
+			Message:    "invalid pointer",
+			Definition: notNullable,
+			Input:      &greater,
+			Error: &numbers.MaximumValidationError{
+				Definition: numbers.MaximumValidatorDefinition{Maximum: 1.5},
+				Input:      2,
+			},
+		},
+		{
+			Message:    "valid sql.NullFloat64",
+			Definition: notNullable,
+			Input:      sql.NullFloat64{Float64: 1.5, Valid: true},
+			Error:      nil,
+		},
+		{
+			Message:    "invalid dbr.NullFloat64",
+			Definition: notNullable,
+			Input:      dbr.NewNullFloat64(2.0),
+			Error: &numbers.MaximumValidationError{
+				Definition: numbers.MaximumValidatorDefinition{Maximum: 1.5},
+				Input:      2,
+			},
+		},
+		{
+			Message:    "NULL with nullable",
+			Definition: nullable,
+			Input:      dbr.NewNullFloat64(nil),
+			Error:      nil,
+		},
+		{
+			Message:    "nil with nullable",
+			Definition: nullable,
+			Input:      nil,
+			Error:      nil,
+		},
+		{
+			Message:    "NULL without nullable",
+			Definition: notNullable,
+			Input:      sql.NullFloat64{},
+			Error: &numbers.NullValidationError{
+				Definition: notNullable,
+				Input:      sql.NullFloat64{},
+			},
+		},
+		{
+			Message:    "wrong type",
+			Definition: notNullable,
+			Input:      sql.NullString{String: "10", Valid: true},
+			Error:      &numbers.ValueTypeError{Input: sql.NullString{String: "10", Valid: true}},
+		},
+	}
+	for _, c := range cases {
+		v, err := numbers.NewValueValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
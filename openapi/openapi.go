@@ -0,0 +1,562 @@
+// Package openapi loads the schemas of the operations of OpenAPI 3.0 and 3.1 documents.
+//
+// The schema objects of OpenAPI are converted to JSON Schema before they are
+// compiled. In 3.0, nullable adds null to type and enum, and the schemas are
+// compiled as draft-04. In 3.1, the schemas are compiled as 2020-12. The
+// readOnly properties are not allowed in the requests, and the writeOnly
+// properties are not allowed in the responses. The discriminator selects the
+// branch of oneOf and anyOf by the property.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-jstmpl/go-jsvalidator/httpvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+var (
+	VersionError               = errors.New("the document is not an OpenAPI 3.0 or 3.1 document")
+	UnresolvableReferenceError = errors.New("the reference can't be resolved in the document")
+)
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// ignoredHeaders are the header parameters which OpenAPI ignores.
+var ignoredHeaders = map[string]bool{"accept": true, "content-type": true, "authorization": true}
+
+// Document is the compiled schemas of an OpenAPI document.
+type Document struct {
+	// Version is the openapi field of the document.
+	Version string
+	// Schemas are the schemas in components/schemas by their names.
+	// Their readOnly and writeOnly properties are kept.
+	Schemas map[string]*schema.Schema
+	// Operations are sorted by their paths in the document.
+	Operations []*Operation
+}
+
+// Operation is the schemas of the requests and the responses of an operation.
+// The nil schemas are not described in the document.
+type Operation struct {
+	ID     string
+	Method string
+	// Path is the path template such as "/users/{id}".
+	Path string
+	// Body is the schema of the JSON request body.
+	Body         *schema.Schema
+	BodyRequired bool
+	// Query is the schema of the object of the query parameters, whose
+	// values are strings unless they are converted with Coerce of schema.Schema.
+	Query *schema.Schema
+	// Header is the schema of the object of the header parameters,
+	// whose names are in lower case.
+	Header *schema.Schema
+	// Responses are the schemas of the JSON response bodies by the status
+	// codes, where the default response is 0. The ranges such as 2XX are ignored.
+	Responses map[int]*schema.Schema
+}
+
+// Route returns the route of httpvalidator which validates the operation.
+func (o *Operation) Route() *httpvalidator.Route {
+	return &httpvalidator.Route{
+		Body:         o.Body,
+		OptionalBody: !o.BodyRequired,
+		Query:        o.Query,
+		Header:       o.Header,
+		Responses:    o.Responses,
+	}
+}
+
+// Operation returns the operation of method to the path template, or nil.
+func (d *Document) Operation(method, path string) *Operation {
+	for _, o := range d.Operations {
+		if o.Path == path && strings.EqualFold(o.Method, method) {
+			return o
+		}
+	}
+	return nil
+}
+
+// LoadFile loads the OpenAPI document in the JSON or YAML file.
+func LoadFile(path string) (*Document, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+// Load loads the OpenAPI document in JSON or YAML. Only the local references
+// in the document are resolved.
+func Load(data []byte) (*Document, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, VersionError
+	}
+	l := &loader{doc: root}
+	l.version, _ = root["openapi"].(string)
+	switch {
+	case strings.HasPrefix(l.version, "3.0."):
+		l.draft = schema.Draft04
+	case strings.HasPrefix(l.version, "3.1."):
+		l.draft = schema.Draft202012
+	default:
+		return nil, VersionError
+	}
+	return l.load()
+}
+
+// direction of the messages, which decides the properties which are not allowed.
+type direction int
+
+const (
+	neutral direction = iota
+	request
+	response
+)
+
+type loader struct {
+	doc     map[string]interface{}
+	version string
+	draft   schema.Draft
+}
+
+// build is a document which has the converted schemas at their locations
+// in the original document, and the references to compile in it.
+type build struct {
+	l    *loader
+	dir  direction
+	doc  map[string]interface{}
+	refs []string
+	sets []func(*schema.Schema)
+}
+
+func (l *loader) newBuild(dir direction) *build {
+	b := &build{l: l, dir: dir, doc: map[string]interface{}{}}
+	schemas, _ := child(l.doc, "components", "schemas").(map[string]interface{})
+	for name, raw := range schemas {
+		b.put([]string{"components", "schemas", name}, raw)
+	}
+	return b
+}
+
+// put puts the converted raw at the location of tokens.
+func (b *build) put(tokens []string, raw interface{}) {
+	m := b.doc
+	for _, token := range tokens[:len(tokens)-1] {
+		next, ok := m[token].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[token] = next
+		}
+		m = next
+	}
+	m[tokens[len(tokens)-1]] = b.l.convert(deepCopy(raw), b.dir)
+}
+
+// add puts raw, and passes the compiled schema to set.
+func (b *build) add(tokens []string, raw interface{}, set func(*schema.Schema)) {
+	b.put(tokens, raw)
+	b.ref(tokens, set)
+}
+
+// ref passes the compiled schema at the location of tokens to set.
+func (b *build) ref(tokens []string, set func(*schema.Schema)) {
+	b.refs = append(b.refs, pointer(tokens))
+	b.sets = append(b.sets, set)
+}
+
+func (b *build) compile() error {
+	c := schema.NewCompiler()
+	c.Draft = b.l.draft
+	c.Discriminator = true
+	ss, err := c.CompileRefs(b.doc, b.refs...)
+	if err != nil {
+		return err
+	}
+	for i, s := range ss {
+		b.sets[i](s)
+	}
+	return nil
+}
+
+func (l *loader) load() (*Document, error) {
+	d := &Document{Version: l.version, Schemas: map[string]*schema.Schema{}}
+	neutralBuild := l.newBuild(neutral)
+	schemas, _ := child(l.doc, "components", "schemas").(map[string]interface{})
+	for _, name := range sortedKeys(schemas) {
+		name := name
+		neutralBuild.ref([]string{"components", "schemas", name}, func(s *schema.Schema) { d.Schemas[name] = s })
+	}
+	if err := neutralBuild.compile(); err != nil {
+		return nil, err
+	}
+
+	requestBuild, responseBuild := l.newBuild(request), l.newBuild(response)
+	paths, _ := l.doc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, itemTokens, err := l.deref(paths[path], []string{"paths", path})
+		if err != nil {
+			return nil, err
+		}
+		for _, method := range methods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			o := &Operation{Method: strings.ToUpper(method), Path: path}
+			o.ID, _ = op["operationId"].(string)
+			tokens := append(append([]string{}, itemTokens...), method)
+			if err := l.parameters(requestBuild, o, item, itemTokens, op, tokens); err != nil {
+				return nil, err
+			}
+			if err := l.requestBody(requestBuild, o, op, tokens); err != nil {
+				return nil, err
+			}
+			if err := l.responses(responseBuild, o, op, tokens); err != nil {
+				return nil, err
+			}
+			d.Operations = append(d.Operations, o)
+		}
+	}
+	if err := requestBuild.compile(); err != nil {
+		return nil, err
+	}
+	if err := responseBuild.compile(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// parameters adds the schemas of the query and the header parameters of the
+// operation, where the parameters of the operation override the ones of the path.
+func (l *loader) parameters(b *build, o *Operation, item map[string]interface{}, itemTokens []string, op map[string]interface{}, tokens []string) error {
+	type parameter struct {
+		name     string
+		required bool
+		ref      string
+	}
+	params := map[string]map[string]*parameter{"query": {}, "header": {}}
+	for _, source := range []struct {
+		m      map[string]interface{}
+		tokens []string
+	}{{item, itemTokens}, {op, tokens}} {
+		list, _ := source.m["parameters"].([]interface{})
+		for i, raw := range list {
+			paramTokens := append(append([]string{}, source.tokens...), "parameters", strconv.Itoa(i))
+			param, paramTokens, err := l.deref(raw, paramTokens)
+			if err != nil {
+				return err
+			}
+			in, _ := param["in"].(string)
+			name, _ := param["name"].(string)
+			raw, ok := param["schema"]
+			if params[in] == nil || !ok {
+				continue
+			}
+			if in == "header" {
+				name = strings.ToLower(name)
+				if ignoredHeaders[name] {
+					continue
+				}
+			}
+			schemaTokens := append(paramTokens, "schema")
+			b.put(schemaTokens, raw)
+			required, _ := param["required"].(bool)
+			params[in][name] = &parameter{name, required, pointer(schemaTokens)}
+		}
+	}
+	for _, in := range []string{"query", "header"} {
+		if len(params[in]) == 0 {
+			continue
+		}
+		properties := map[string]interface{}{}
+		var required []interface{}
+		for name, p := range params[in] {
+			properties[name] = map[string]interface{}{"$ref": p.ref}
+			if p.required {
+				required = append(required, name)
+			}
+		}
+		obj := map[string]interface{}{"type": "object", "properties": properties}
+		if required != nil {
+			sort.Slice(required, func(i, j int) bool { return required[i].(string) < required[j].(string) })
+			obj["required"] = required
+		}
+		set := func(s *schema.Schema) { o.Query = s }
+		if in == "header" {
+			set = func(s *schema.Schema) { o.Header = s }
+		}
+		b.add(append(append([]string{}, tokens...), "x-jsvalidator-"+in), obj, set)
+	}
+	return nil
+}
+
+func (l *loader) requestBody(b *build, o *Operation, op map[string]interface{}, tokens []string) error {
+	raw, ok := op["requestBody"]
+	if !ok {
+		return nil
+	}
+	body, bodyTokens, err := l.deref(raw, append(append([]string{}, tokens...), "requestBody"))
+	if err != nil {
+		return err
+	}
+	o.BodyRequired, _ = body["required"].(bool)
+	if mediaType, s, ok := jsonSchema(body); ok {
+		b.add(append(bodyTokens, "content", mediaType, "schema"), s, func(s *schema.Schema) { o.Body = s })
+	}
+	return nil
+}
+
+func (l *loader) responses(b *build, o *Operation, op map[string]interface{}, tokens []string) error {
+	responses, _ := op["responses"].(map[string]interface{})
+	for _, code := range sortedKeys(responses) {
+		status := 0
+		if code != "default" {
+			n, err := strconv.Atoi(code)
+			if err != nil {
+				continue
+			}
+			status = n
+		}
+		res, resTokens, err := l.deref(responses[code], append(append([]string{}, tokens...), "responses", code))
+		if err != nil {
+			return err
+		}
+		if mediaType, s, ok := jsonSchema(res); ok {
+			if o.Responses == nil {
+				o.Responses = map[int]*schema.Schema{}
+			}
+			b.add(append(resTokens, "content", mediaType, "schema"), s, func(s *schema.Schema) { o.Responses[status] = s })
+		}
+	}
+	return nil
+}
+
+// jsonSchema returns the schema of application/json in the content of m,
+// or of the first media type with the +json suffix.
+func jsonSchema(m map[string]interface{}) (string, interface{}, bool) {
+	content, _ := m["content"].(map[string]interface{})
+	mediaTypes := sortedKeys(content)
+	for _, mediaType := range mediaTypes {
+		if mediaType == "application/json" {
+			s := child(content, mediaType, "schema")
+			return mediaType, s, s != nil
+		}
+	}
+	for _, mediaType := range mediaTypes {
+		if strings.HasSuffix(strings.SplitN(mediaType, ";", 2)[0], "+json") {
+			s := child(content, mediaType, "schema")
+			return mediaType, s, s != nil
+		}
+	}
+	return "", nil, false
+}
+
+// deref follows the local reference of the OpenAPI object raw, and returns
+// the object and its location.
+func (l *loader) deref(raw interface{}, tokens []string) (map[string]interface{}, []string, error) {
+	for i := 0; i < 32; i++ {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid object at '%s'", pointer(tokens))
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m, tokens, nil
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, nil, fmt.Errorf("%s: %s", ref, UnresolvableReferenceError)
+		}
+		tokens = splitPointer(ref)
+		raw = child(l.doc, tokens...)
+	}
+	return nil, nil, fmt.Errorf("invalid object at '%s': %s", pointer(tokens), UnresolvableReferenceError)
+}
+
+// convert converts the schema object raw of OpenAPI to JSON Schema in place.
+func (l *loader) convert(raw interface{}, dir direction) interface{} {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+	delete(m, "example")
+	if l.draft == schema.Draft04 {
+		if nullable, _ := m["nullable"].(bool); nullable {
+			switch t := m["type"].(type) {
+			case string:
+				m["type"] = []interface{}{t, "null"}
+			case []interface{}:
+				m["type"] = append(t, "null")
+			}
+			if enum, ok := m["enum"].([]interface{}); ok {
+				m["enum"] = append(enum, nil)
+			}
+		}
+		delete(m, "nullable")
+	}
+
+	if properties, ok := m["properties"].(map[string]interface{}); ok && dir != neutral {
+		flag := "readOnly"
+		if dir == response {
+			flag = "writeOnly"
+		}
+		required, _ := m["required"].([]interface{})
+		for name, p := range properties {
+			if !l.flagged(p, flag) {
+				continue
+			}
+			properties[name] = map[string]interface{}{"not": map[string]interface{}{}}
+			for i := 0; i < len(required); i++ {
+				if required[i] == name {
+					required = append(required[:i], required[i+1:]...)
+					i--
+				}
+			}
+		}
+		if len(required) > 0 {
+			m["required"] = required
+		} else {
+			delete(m, "required")
+		}
+	}
+
+	for key, v := range m {
+		switch key {
+		case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+			if sub, ok := v.(map[string]interface{}); ok {
+				for name, s := range sub {
+					sub[name] = l.convert(s, dir)
+				}
+			}
+		case "items", "allOf", "anyOf", "oneOf", "prefixItems":
+			if list, ok := v.([]interface{}); ok {
+				for i, s := range list {
+					list[i] = l.convert(s, dir)
+				}
+				continue
+			}
+			m[key] = l.convert(v, dir)
+		case "additionalProperties", "additionalItems", "not", "if", "then", "else",
+			"contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties":
+			m[key] = l.convert(v, dir)
+		}
+	}
+	return m
+}
+
+// flagged returns whether the schema raw, or the schemas which it references
+// locally, has the flag such as readOnly.
+func (l *loader) flagged(raw interface{}, flag string) bool {
+	for i := 0; i < 32; i++ {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if v, _ := m[flag].(bool); v {
+			return true
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return false
+		}
+		raw = child(l.doc, splitPointer(ref)...)
+	}
+	return false
+}
+
+func child(raw interface{}, tokens ...string) interface{} {
+	for _, token := range tokens {
+		switch v := raw.(type) {
+		case map[string]interface{}:
+			raw = v[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			raw = v[i]
+		default:
+			return nil
+		}
+	}
+	return raw
+}
+
+func pointer(tokens []string) string {
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = schema.EscapePointer(token)
+	}
+	return "#/" + strings.Join(escaped, "/")
+}
+
+func splitPointer(ref string) []string {
+	tokens := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens
+}
+
+func deepCopy(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, e := range v {
+			m[key] = deepCopy(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, e := range v {
+			a[i] = deepCopy(e)
+		}
+		return a
+	}
+	return raw
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// decode decodes the JSON or YAML document.
+func decode(data []byte) (interface{}, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+		var v interface{}
+		if err := d.Decode(&v); err != nil {
+			return nil, err
+		}
+		if _, err := d.Token(); err != io.EOF {
+			return nil, errors.New("invalid data after the top-level value")
+		}
+		return v, nil
+	}
+	// the status codes of the responses are often written as integers
+	d, err := schema.YAMLOptions{ScalarKeys: true}.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return d.Value, nil
+}
@@ -0,0 +1,303 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/httpvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/openapi"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestLoadFile(t *testing.T) {
+	type Case struct {
+		Message  string
+		File     string
+		Method   string
+		Path     string
+		Schema   func(o *openapi.Operation) *schema.Schema
+		Instance string
+		Valid    bool
+	}
+	body := func(o *openapi.Operation) *schema.Schema { return o.Body }
+	query := func(o *openapi.Operation) *schema.Schema { return o.Query }
+	header := func(o *openapi.Operation) *schema.Schema { return o.Header }
+	response := func(status int) func(o *openapi.Operation) *schema.Schema {
+		return func(o *openapi.Operation) *schema.Schema { return o.Responses[status] }
+	}
+	cases := []Case{
+		{
+			Message:  "3.0 request without readOnly property",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"name": "Tama", "password": "12345678"}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.0 request with readOnly property",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"id": 1, "name": "Tama", "password": "12345678"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 request with null by nullable",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"name": "Tama", "password": "12345678", "status": null}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.0 request with null without nullable",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"name": null, "password": "12345678"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 response without writeOnly property",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   response(http.StatusCreated),
+			Instance: `{"id": 1, "name": "Tama"}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.0 response with writeOnly property by $ref",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   response(http.StatusCreated),
+			Instance: `{"id": 1, "name": "Tama", "password": "12345678"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 response with readOnly property missing",
+			File:     "testdata/petstore.yaml",
+			Method:   "GET",
+			Path:     "/pets",
+			Schema:   response(http.StatusOK),
+			Instance: `[{"name": "Tama"}]`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 default response by $ref",
+			File:     "testdata/petstore.yaml",
+			Method:   "GET",
+			Path:     "/pets",
+			Schema:   response(0),
+			Instance: `{}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 query parameters",
+			File:     "testdata/petstore.yaml",
+			Method:   "GET",
+			Path:     "/pets",
+			Schema:   query,
+			Instance: `{"limit": "ten"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 header parameters of the path",
+			File:     "testdata/petstore.yaml",
+			Method:   "GET",
+			Path:     "/pets",
+			Schema:   header,
+			Instance: `{"accept": "application/json"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.0 request of +json media type",
+			File:     "testdata/petstore.yaml",
+			Method:   "PATCH",
+			Path:     "/pets/{id}",
+			Schema:   body,
+			Instance: `{"tag": null}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.0 request selected by discriminator",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets/events",
+			Schema:   body,
+			Instance: `{"event": "returned", "reason": "allergy"}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.0 request with unknown discriminator",
+			File:     "testdata/petstore.yaml",
+			Method:   "POST",
+			Path:     "/pets/events",
+			Schema:   body,
+			Instance: `{"event": "lost"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.1 request without readOnly property",
+			File:     "testdata/petstore.json",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"name": "Tama", "tag": null, "password": "secret"}`,
+			Valid:    true,
+		},
+		{
+			Message:  "3.1 request with readOnly property",
+			File:     "testdata/petstore.json",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   body,
+			Instance: `{"id": 1, "name": "Tama"}`,
+			Valid:    false,
+		},
+		{
+			Message:  "3.1 response with writeOnly property",
+			File:     "testdata/petstore.json",
+			Method:   "POST",
+			Path:     "/pets",
+			Schema:   response(http.StatusCreated),
+			Instance: `{"id": 1, "name": "Tama", "password": "secret"}`,
+			Valid:    false,
+		},
+	}
+
+	for _, c := range cases {
+		d, err := openapi.LoadFile(c.File)
+		if err != nil {
+			t.Fatalf("Test with %s: %s", c.Message, err)
+		}
+		o := d.Operation(c.Method, c.Path)
+		if o == nil {
+			t.Errorf("Test with %s: expected the operation %s %s", c.Message, c.Method, c.Path)
+			continue
+		}
+		s := c.Schema(o)
+		if s == nil {
+			t.Errorf("Test with %s: expected the schema", c.Message)
+			continue
+		}
+		var instance interface{}
+		if err := json.Unmarshal([]byte(c.Instance), &instance); err != nil {
+			t.Fatal(err)
+		}
+		err = s.Validate(instance)
+		if valid := err == nil; valid != c.Valid {
+			t.Errorf("Test with %s: expected %v, but actual %v (%v)", c.Message, c.Valid, valid, err)
+		}
+	}
+}
+
+func TestLoadFileOperations(t *testing.T) {
+	d, err := openapi.LoadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Version != "3.0.3" {
+		t.Errorf("expected 3.0.3, but actual %s", d.Version)
+	}
+	var ids []string
+	for _, o := range d.Operations {
+		ids = append(ids, o.ID)
+	}
+	if expected := "listPets createPet createPetEvent updatePet"; strings.Join(ids, " ") != expected {
+		t.Errorf("expected %s, but actual %v", expected, ids)
+	}
+	if o := d.Operation("post", "/pets"); len(o.Responses) != 1 || o.Query != nil {
+		t.Errorf("expected only the response of 201, but actual %v", o.Responses)
+	}
+
+	var pet interface{}
+	json.Unmarshal([]byte(`{"id": 1, "name": "Tama", "password": "12345678"}`), &pet)
+	if err := d.Schemas["Pet"].Validate(pet); err != nil {
+		t.Errorf("expected the component schema to allow both readOnly and writeOnly, but actual %v", err)
+	}
+}
+
+func TestLoadWithErrors(t *testing.T) {
+	type Case struct {
+		Message  string
+		Document string
+	}
+	cases := []Case{
+		{
+			Message:  "Swagger 2.0",
+			Document: `{"swagger": "2.0"}`,
+		},
+		{
+			Message:  "unresolvable reference",
+			Document: `{"openapi": "3.0.0", "paths": {"/": {"get": {"parameters": [{"$ref": "#/components/parameters/Missing"}]}}}}`,
+		},
+		{
+			Message:  "invalid schema",
+			Document: "openapi: 3.1.0\ncomponents:\n  schemas:\n    Name:\n      maxLength: -1\n",
+		},
+	}
+	for _, c := range cases {
+		if _, err := openapi.Load([]byte(c.Document)); err == nil {
+			t.Errorf("Test with %s: expected an error", c.Message)
+		}
+	}
+}
+
+func TestLoadWithYAMLKey(t *testing.T) {
+	_, err := openapi.Load([]byte("openapi: 3.1.0\npaths:\n  /:\n    get:\n      responses:\n        [200]: {}\n"))
+	expected := &schema.YAMLError{Line: 6, Column: 9, Err: schema.YAMLKeyError}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestOperationRoute(t *testing.T) {
+	d, err := openapi.LoadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := httpvalidator.New()
+	handler := v.Handler(d.Operation("PATCH", "/pets/{id}").Route(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	type Case struct {
+		Message string
+		Body    string
+		Status  int
+	}
+	cases := []Case{
+		{
+			Message: "optional body",
+			Body:    "",
+			Status:  http.StatusOK,
+		},
+		{
+			Message: "valid body",
+			Body:    `{"tag": "cat"}`,
+			Status:  http.StatusOK,
+		},
+		{
+			Message: "invalid body",
+			Body:    `{"tag": 1}`,
+			Status:  http.StatusUnprocessableEntity,
+		},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("PATCH", "/pets/1", strings.NewReader(c.Body)))
+		if w.Code != c.Status {
+			t.Errorf("Test with %s: expected %d, but actual %d", c.Message, c.Status, w.Code)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+package validator
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/gocraft/dbr"
+)
+
+// UnsupportedTypeError for Reflect
+type UnsupportedTypeError struct {
+	Type string `json:"type"`
+}
+
+func (e UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("the type %s can't be represented in JSON Schema", e.Type)
+}
+
+// Reflector generates JSON Schema documents from Go types.
+type Reflector struct {
+	// Draft is the draft of the generated documents.
+	Draft schema.Draft
+}
+
+func NewReflector() *Reflector {
+	return &Reflector{Draft: schema.DefaultDraft}
+}
+
+// Reflect generates the JSON Schema document of the type of v with the default Reflector.
+func Reflect(v interface{}) (map[string]interface{}, error) {
+	return NewReflector().Reflect(v)
+}
+
+// Reflect generates the JSON Schema document of the type of v.
+//
+// The types of the schemas are decided by the kinds of the Go types, and
+// the named struct types are defined in $defs (definitions before 2019-09)
+// and referenced by $ref. The fields are named by json tags, and are
+// required when they are tagged `jsonschema:"required"`, or they are
+// neither pointers nor omitempty. The dbr.Null* and sql.Null* types and
+// the pointers without omitempty are nullable. The keywords in jsonschema
+// tags, which ValidateStruct evaluates, are added to the schemas of the fields.
+func (r *Reflector) Reflect(v interface{}) (map[string]interface{}, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, &UnsupportedTypeError{"nil"}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	draft := r.Draft
+	if draft == 0 {
+		draft = schema.DefaultDraft
+	}
+	rf := &reflection{
+		draft: draft,
+		refs:  map[reflect.Type]string{},
+		names: map[string]bool{},
+		defs:  map[string]interface{}{},
+	}
+	rf.defsKey = "$defs"
+	if draft <= schema.Draft07 {
+		rf.defsKey = "definitions"
+	}
+
+	var doc map[string]interface{}
+	var err error
+	if t.Kind() == reflect.Struct && t.Name() != "" && !isNullType(t) {
+		rf.refs[t] = "#"
+		doc, err = rf.structSchema(t)
+	} else {
+		doc, err = rf.typeSchema(t)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc["$schema"] = draft.URI()
+	if len(rf.defs) > 0 {
+		doc[rf.defsKey] = rf.defs
+	}
+	return doc, nil
+}
+
+type reflection struct {
+	draft   schema.Draft
+	defsKey string
+	refs    map[reflect.Type]string
+	names   map[string]bool
+	defs    map[string]interface{}
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	nullTypes = map[reflect.Type]string{
+		reflect.TypeOf(dbr.NullString{}):  "string",
+		reflect.TypeOf(dbr.NullInt64{}):   "integer",
+		reflect.TypeOf(dbr.NullFloat64{}): "number",
+		reflect.TypeOf(dbr.NullBool{}):    "boolean",
+		reflect.TypeOf(dbr.NullTime{}):    "string",
+		reflect.TypeOf(sql.NullString{}):  "string",
+		reflect.TypeOf(sql.NullInt64{}):   "integer",
+		reflect.TypeOf(sql.NullFloat64{}): "number",
+		reflect.TypeOf(sql.NullBool{}):    "boolean",
+		reflect.TypeOf(sql.NullTime{}):    "string",
+		reflect.TypeOf(sql.NullInt32{}):   "integer",
+		reflect.TypeOf(sql.NullInt16{}):   "integer",
+		reflect.TypeOf(sql.NullByte{}):    "integer",
+	}
+)
+
+func isNullType(t reflect.Type) bool {
+	_, ok := nullTypes[t]
+	return ok
+}
+
+// typeSchema returns the schema of t.
+func (rf *reflection) typeSchema(t reflect.Type) (map[string]interface{}, error) {
+	if name, ok := nullTypes[t]; ok {
+		s := map[string]interface{}{"type": []interface{}{name, "null"}}
+		if t == reflect.TypeOf(dbr.NullTime{}) || t == reflect.TypeOf(sql.NullTime{}) {
+			s["format"] = "date-time"
+		}
+		return s, nil
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Ptr:
+		return rf.typeSchema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := rf.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := map[string]interface{}{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			s["minItems"] = t.Len()
+			s["maxItems"] = t.Len()
+		}
+		return s, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, &UnsupportedTypeError{t.String()}
+		}
+		values, err := rf.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t.Name() == "" {
+			return rf.structSchema(t)
+		}
+		return rf.ref(t)
+	}
+	return nil, &UnsupportedTypeError{t.String()}
+}
+
+// ref defines the named struct type t and returns the schema which references it.
+func (rf *reflection) ref(t reflect.Type) (map[string]interface{}, error) {
+	if ref, ok := rf.refs[t]; ok {
+		return map[string]interface{}{"$ref": ref}, nil
+	}
+	name := t.Name()
+	for i := 2; rf.names[name]; i++ {
+		name = t.Name() + strconv.Itoa(i)
+	}
+	rf.names[name] = true
+	ref := "#/" + rf.defsKey + "/" + name
+	rf.refs[t] = ref
+	s, err := rf.structSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	rf.defs[name] = s
+	return map[string]interface{}{"$ref": ref}, nil
+}
+
+// structSchema returns the object schema of the struct type t.
+func (rf *reflection) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []interface{}
+	if err := rf.fields(t, properties, &required); err != nil {
+		return nil, err
+	}
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s, nil
+}
+
+// fields adds the schemas of the fields of t to properties.
+func (rf *reflection) fields(t reflect.Type, properties map[string]interface{}, required *[]interface{}) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name, inline := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		if inline {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if err := rf.fields(ft, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+
+		s, err := rf.typeSchema(f.Type)
+		if err != nil {
+			return err
+		}
+		keywords, err := parseTag(f.Tag.Get(TagName))
+		if err != nil {
+			return &TagDefinitionError{t.Name(), f.Name, "", err}
+		}
+		omitempty := strings.Contains(f.Tag.Get("json"), ",omitempty")
+		isRequired := f.Type.Kind() != reflect.Ptr && !omitempty
+		nullable := f.Type.Kind() == reflect.Ptr && !omitempty
+		constraints := map[string]interface{}{}
+		for _, k := range keywords {
+			if k.key == "required" {
+				isRequired = true
+				continue
+			}
+			if _, err := newKeywordValidator(f.Type, k.key, k.value); err != nil {
+				return &TagDefinitionError{t.Name(), f.Name, k.key, err}
+			}
+			rf.keyword(constraints, f.Type, k)
+		}
+		// the null types allow null in enum like the nullable pointers
+		properties[name] = rf.merge(s, constraints, nullable || isNullType(f.Type))
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// keyword adds the keyword of jsonschema tag to s.
+// The value has already been checked by newKeywordValidator.
+func (rf *reflection) keyword(s map[string]interface{}, t reflect.Type, k tagKeyword) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch k.key {
+	case "maxLength", "minLength", "maxItems", "minItems":
+		n, _ := strconv.Atoi(k.value)
+		s[k.key] = n
+	case "pattern", "format":
+		s[k.key] = k.value
+	case "enum":
+		var enum []interface{}
+		for _, v := range strings.Split(k.value, "|") {
+			enum = append(enum, tagValue(t, v))
+		}
+		s["enum"] = enum
+	case "maximum", "minimum":
+		s[k.key] = tagValue(t, k.value)
+	case "exclusiveMaximum", "exclusiveMinimum":
+		if rf.draft > schema.Draft04 {
+			s[k.key] = tagValue(t, k.value)
+			return
+		}
+		limit := "maximum"
+		if k.key == "exclusiveMinimum" {
+			limit = "minimum"
+		}
+		s[limit] = tagValue(t, k.value)
+		s[k.key] = true
+	}
+}
+
+// tagValue converts the value in jsonschema tag to the JSON value for the field of type t.
+// The values for the null types are converted by their JSON types.
+func tagValue(t reflect.Type, v string) interface{} {
+	kind := t.Kind()
+	switch nullTypes[t] {
+	case "integer":
+		kind = reflect.Int64
+	case "number":
+		kind = reflect.Float64
+	case "boolean":
+		kind = reflect.Bool
+	case "string":
+		kind = reflect.String
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, _ := strconv.Atoi(v)
+		return n
+	case reflect.Float32, reflect.Float64:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	case reflect.Bool:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return v
+}
+
+// merge adds the constraints to s, and makes s nullable if nullable is true.
+func (rf *reflection) merge(s, constraints map[string]interface{}, nullable bool) map[string]interface{} {
+	if _, ok := s["$ref"]; ok {
+		if nullable {
+			s = map[string]interface{}{"anyOf": []interface{}{s, map[string]interface{}{"type": "null"}}}
+		}
+		return s
+	}
+	for k, v := range constraints {
+		s[k] = v
+	}
+	if nullable {
+		if t, ok := s["type"].(string); ok {
+			s["type"] = []interface{}{t, "null"}
+		}
+		if enum, ok := s["enum"].([]interface{}); ok {
+			s["enum"] = append(enum, nil)
+		}
+	}
+	return s
+}
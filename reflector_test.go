@@ -0,0 +1,271 @@
+package validator_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-jstmpl/go-jsvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/gocraft/dbr"
+)
+
+type Node struct {
+	Value    int     `json:"value" jsonschema:"exclusiveMinimum=0"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+type Article struct {
+	ID        uint64            `json:"id"`
+	Title     string            `json:"title" jsonschema:"minLength=1,maxLength=100"`
+	Body      dbr.NullString    `json:"body"`
+	Status    string            `json:"status" jsonschema:"enum=draft|published"`
+	Rate      float64           `json:"rate,omitempty" jsonschema:"required,maximum=5"`
+	Author    *Node             `json:"author"`
+	Tags      []string          `json:"tags,omitempty" jsonschema:"maxItems=3"`
+	Meta      map[string]string `json:"meta,omitempty"`
+	Note      *string           `json:"note"`
+	CreatedAt time.Time         `json:"created_at"`
+	Ignored   string            `json:"-"`
+}
+
+func TestReflect(t *testing.T) {
+	doc, err := validator.Reflect(Article{})
+	if err != nil {
+		t.Fatalf("Fail to Reflect: %s", err)
+	}
+	expected := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "minimum": 0},
+			"title": {"type": "string", "minLength": 1, "maxLength": 100},
+			"body": {"type": ["string", "null"]},
+			"status": {"type": "string", "enum": ["draft", "published"]},
+			"rate": {"type": "number", "maximum": 5},
+			"author": {"anyOf": [{"$ref": "#/$defs/Node"}, {"type": "null"}]},
+			"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 3},
+			"meta": {"type": "object", "additionalProperties": {"type": "string"}},
+			"note": {"type": ["string", "null"]},
+			"created_at": {"type": "string", "format": "date-time"}
+		},
+		"required": ["id", "title", "body", "status", "rate", "created_at"],
+		"$defs": {
+			"Node": {
+				"type": "object",
+				"properties": {
+					"value": {"type": "integer", "exclusiveMinimum": 0},
+					"children": {"type": "array", "items": {"$ref": "#/$defs/Node"}}
+				},
+				"required": ["value"]
+			}
+		}
+	}`
+	assertJSONEqual(t, "Article", doc, expected)
+}
+
+func TestReflectWithDraft04(t *testing.T) {
+	r := validator.NewReflector()
+	r.Draft = schema.Draft04
+	doc, err := r.Reflect(&Node{})
+	if err != nil {
+		t.Fatalf("Fail to Reflect: %s", err)
+	}
+	expected := `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"value": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+			"children": {"type": "array", "items": {"$ref": "#"}}
+		},
+		"required": ["value"]
+	}`
+	assertJSONEqual(t, "Node", doc, expected)
+}
+
+func TestReflectWithInvalidType(t *testing.T) {
+	type Invalid struct {
+		C chan int
+	}
+	type InvalidTag struct {
+		Count int `jsonschema:"maxLength=1"`
+	}
+	type Case struct {
+		Message string
+		Input   interface{}
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "channel",
+			Input:   Invalid{},
+			Error:   &validator.UnsupportedTypeError{Type: "chan int"},
+		},
+		{
+			Message: "invalid tag",
+			Input:   InvalidTag{},
+			Error:   &validator.TagDefinitionError{Type: "InvalidTag", Field: "Count", Keyword: "maxLength", Err: validator.TagUnknownKeywordError},
+		},
+	}
+	for _, c := range cases {
+		if _, err := validator.Reflect(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestReflectAgreesWithValidateStruct(t *testing.T) {
+	doc, err := validator.Reflect(Article{})
+	if err != nil {
+		t.Fatalf("Fail to Reflect: %s", err)
+	}
+	s, err := schema.Compile(roundTrip(t, doc))
+	if err != nil {
+		t.Fatalf("Fail to Compile: %s", err)
+	}
+
+	type Case struct {
+		Message string
+		Input   Article
+		Valid   bool
+	}
+	cases := []Case{
+		{
+			Message: "valid",
+			Input:   Article{Title: "foo", Status: "draft", Rate: 1, Author: &Node{Value: 1, Children: []*Node{{Value: 2}}}},
+			Valid:   true,
+		},
+		{
+			Message: "invalid field",
+			Input:   Article{Title: "", Status: "draft", Rate: 1},
+		},
+		{
+			Message: "invalid enum",
+			Input:   Article{Title: "foo", Status: "deleted", Rate: 1},
+		},
+		{
+			Message: "invalid recursive struct",
+			Input:   Article{Title: "foo", Status: "draft", Rate: 1, Author: &Node{Value: 1, Children: []*Node{{Value: 0}}}},
+		},
+	}
+	for _, c := range cases {
+		if valid := validator.ValidateStruct(c.Input) == nil; valid != c.Valid {
+			t.Errorf("Test with %s: expected ValidateStruct %t, but actual %t", c.Message, c.Valid, valid)
+		}
+		if err := s.Validate(roundTrip(t, c.Input)); (err == nil) != c.Valid {
+			t.Errorf("Test with %s: expected Validate %t, but actual %v", c.Message, c.Valid, err)
+		}
+	}
+}
+
+type Measure struct {
+	Count  dbr.NullInt64   `json:"count" jsonschema:"maximum=10"`
+	Ratio  dbr.NullFloat64 `json:"ratio" jsonschema:"minimum=0.5"`
+	Unit   dbr.NullString  `json:"unit" jsonschema:"enum=kg|g"`
+	Active dbr.NullBool    `json:"active" jsonschema:"enum=true"`
+	Small  sql.NullInt16   `json:"small" jsonschema:"exclusiveMaximum=8"`
+	Byte   sql.NullByte    `json:"byte" jsonschema:"maximum=255"`
+	Medium sql.NullInt32   `json:"medium" jsonschema:"minimum=-1"`
+	At     sql.NullTime    `json:"at"`
+}
+
+func TestReflectNullTypes(t *testing.T) {
+	doc, err := validator.Reflect(Measure{})
+	if err != nil {
+		t.Fatalf("Fail to Reflect: %s", err)
+	}
+	properties := doc["properties"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"count":  map[string]interface{}{"type": []interface{}{"integer", "null"}, "maximum": 10},
+		"ratio":  map[string]interface{}{"type": []interface{}{"number", "null"}, "minimum": 0.5},
+		"unit":   map[string]interface{}{"type": []interface{}{"string", "null"}, "enum": []interface{}{"kg", "g", nil}},
+		"active": map[string]interface{}{"type": []interface{}{"boolean", "null"}, "enum": []interface{}{true, nil}},
+		"small":  map[string]interface{}{"type": []interface{}{"integer", "null"}, "exclusiveMaximum": 8},
+		"byte":   map[string]interface{}{"type": []interface{}{"integer", "null"}, "maximum": 255},
+		"medium": map[string]interface{}{"type": []interface{}{"integer", "null"}, "minimum": -1},
+		"at":     map[string]interface{}{"type": []interface{}{"string", "null"}, "format": "date-time"},
+	}
+	for name, e := range expected {
+		if !reflect.DeepEqual(properties[name], e) {
+			t.Errorf("Test with %s: expected %v, but actual %v", name, e, properties[name])
+		}
+	}
+	s, err := schema.Compile(roundTrip(t, doc))
+	if err != nil {
+		t.Fatalf("Fail to Compile: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance string
+		Valid    bool
+	}
+	cases := []Case{
+		{
+			Message:  "valid",
+			Instance: `{"count": 10, "ratio": 0.5, "unit": "kg", "active": true, "small": 7, "byte": 1, "medium": -1, "at": null}`,
+			Valid:    true,
+		},
+		{
+			Message:  "nulls",
+			Instance: `{"count": null, "ratio": null, "unit": null, "active": null, "small": null, "byte": null, "medium": null, "at": null}`,
+			Valid:    true,
+		},
+		{
+			Message:  "invalid maximum",
+			Instance: `{"count": 11, "ratio": 0.5, "unit": "kg", "active": true, "small": 7, "byte": 1, "medium": -1, "at": null}`,
+		},
+		{
+			Message:  "invalid minimum",
+			Instance: `{"count": 10, "ratio": 0.4, "unit": "kg", "active": true, "small": 7, "byte": 1, "medium": -1, "at": null}`,
+		},
+		{
+			Message:  "invalid enum",
+			Instance: `{"count": 10, "ratio": 0.5, "unit": "lb", "active": true, "small": 7, "byte": 1, "medium": -1, "at": null}`,
+		},
+		{
+			Message:  "invalid boolean enum",
+			Instance: `{"count": 10, "ratio": 0.5, "unit": "kg", "active": false, "small": 7, "byte": 1, "medium": -1, "at": null}`,
+		},
+	}
+	for _, c := range cases {
+		var instance interface{}
+		if err := json.Unmarshal([]byte(c.Instance), &instance); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Validate(instance); (err == nil) != c.Valid {
+			t.Errorf("Test with %s: expected %t, but actual %v", c.Message, c.Valid, err)
+		}
+	}
+	if err := s.Validate(roundTrip(t, map[string]interface{}{
+		"count": dbr.NewNullInt64(10), "ratio": dbr.NewNullFloat64(0.5), "unit": dbr.NewNullString("g"), "active": dbr.NewNullBool(true),
+		"small": nil, "byte": nil, "medium": nil, "at": nil,
+	})); err != nil {
+		t.Errorf("expected the marshaled dbr values to be valid, but actual %v", err)
+	}
+}
+
+func roundTrip(t *testing.T, v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func assertJSONEqual(t *testing.T, message string, actual interface{}, expected string) {
+	var e interface{}
+	if err := json.Unmarshal([]byte(expected), &e); err != nil {
+		t.Fatal(err)
+	}
+	if a := roundTrip(t, actual); !reflect.DeepEqual(a, e) {
+		b, _ := json.MarshalIndent(actual, "", "  ")
+		t.Errorf("Test with %s: unexpected schema %s", message, b)
+	}
+}
@@ -1,11 +1,14 @@
 package validator
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 
+	"github.com/go-jstmpl/go-jsvalidator/validators"
 	"github.com/gocraft/dbr"
 )
 
@@ -20,6 +23,8 @@ type RequiredValidator struct {
 	definition RequiredValidatorDefinition
 }
 
+var _ validators.Validator[interface{}] = RequiredValidator{}
+
 type RequiredValidatorDefinition struct {
 	Required []string `json:"pattern"`
 }
@@ -134,7 +139,7 @@ func isPresentStruct(v reflect.Value) bool {
 }
 
 // isValid returns whether i is valid.
-// The type of i should be dbr.Null* or primitive.
+// The type of i should be dbr.Null*, sql.Null*, driver.Valuer or primitive.
 func isValid(i interface{}) (ok bool) {
 	switch t := i.(type) {
 	case dbr.NullString:
@@ -142,6 +147,11 @@ func isValid(i interface{}) (ok bool) {
 			return false
 		}
 		return isPresentString(t.String)
+	case sql.NullString:
+		if !t.Valid {
+			return false
+		}
+		return isPresentString(t.String)
 	case dbr.NullInt64:
 		return t.Valid
 	case dbr.NullFloat64:
@@ -152,6 +162,15 @@ func isValid(i interface{}) (ok bool) {
 		return t.Valid
 	case string:
 		return isPresentString(t)
+	case driver.Valuer:
+		v, err := t.Value()
+		if err != nil || v == nil {
+			return false
+		}
+		if s, ok := v.(string); ok {
+			return isPresentString(s)
+		}
+		return true
 	default:
 		v := reflect.ValueOf(t)
 		switch v.Kind() {
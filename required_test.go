@@ -1,6 +1,7 @@
 package validator_test
 
 import (
+	"database/sql"
 	"reflect"
 	"testing"
 	"time"
@@ -563,6 +564,31 @@ func TestIsValid(t *testing.T) {
 			Input:           dbr.NewNullTime(nil),
 			ExpectedIsValid: false,
 		},
+		{
+			Description:     "valid sql nullable string",
+			Input:           sql.NullString{String: "value", Valid: true},
+			ExpectedIsValid: true,
+		},
+		{
+			Description:     "blank sql nullable string",
+			Input:           sql.NullString{String: " ", Valid: true},
+			ExpectedIsValid: false,
+		},
+		{
+			Description:     "valid sql nullable int",
+			Input:           sql.NullInt64{Int64: 0, Valid: true},
+			ExpectedIsValid: true,
+		},
+		{
+			Description:     "invalid sql nullable int",
+			Input:           sql.NullInt64{},
+			ExpectedIsValid: false,
+		},
+		{
+			Description:     "invalid sql nullable time",
+			Input:           sql.NullTime{},
+			ExpectedIsValid: false,
+		},
 	}
 
 	for _, c := range cases {
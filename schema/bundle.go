@@ -0,0 +1,268 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// UnbundlableReferenceError reports the reference from a resource with $id
+// to the root document without $id, which can't be referenced in the bundle.
+var UnbundlableReferenceError = errors.New("the reference to the document without $id can't be bundled")
+
+// Bundle loads the document of uri with Loader like LoadURI, and returns
+// the self-contained document which has the documents referenced by $ref
+// in $defs, or definitions before draft 2019-09. The documents are named
+// after their file names, and the references to them are rewritten. The
+// $ids are preserved, so the references in the resources with $id are
+// rewritten into their URIs, and the documents without $id which they
+// reference are given their URIs as $id. The bundle compiles into the
+// schemas which validate the instances identically.
+func (c *Compiler) Bundle(uri string) (interface{}, error) {
+	return c.bundle(c.Loader, uri)
+}
+
+// BundleFile bundles the document of the file path like Bundle. The
+// documents are loaded with FileLoader if Loader is nil.
+func (c *Compiler) BundleFile(path string) (interface{}, error) {
+	uri, err := fileURI(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.bundle(c.fileLoader(), uri)
+}
+
+func (c *Compiler) bundle(loader Loader, uri string) (interface{}, error) {
+	s, p, err := c.compileURI(loader, uri)
+	if err != nil {
+		return nil, err
+	}
+	root := p.resources[uri]
+	if len(p.documents) == 0 {
+		return root.raw, nil
+	}
+	key := "definitions"
+	if s.Draft >= Draft201909 {
+		key = "$defs"
+	}
+	b := &bundler{p: p, key: key, root: root, uri: uri, ids: map[string]bool{}}
+	for {
+		bundle := b.build()
+		if len(b.errs) > 0 {
+			return nil, b.errs[0]
+		}
+		if len(b.missing) == 0 {
+			return bundle, nil
+		}
+		for u := range b.missing {
+			b.ids[u] = true
+		}
+	}
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// bundleNames names the documents after their file names without the
+// extensions, which are numbered when they are duplicated.
+func bundleNames(uris []string, defs map[string]interface{}) map[string]string {
+	sorted := append([]string{}, uris...)
+	sort.Strings(sorted)
+	used := map[string]bool{}
+	for name := range defs {
+		used[name] = true
+	}
+	names := map[string]string{}
+	for _, uri := range sorted {
+		base := "schema"
+		if u, err := url.Parse(uri); err == nil {
+			name := path.Base(u.Path)
+			name = strings.TrimSuffix(name, path.Ext(name))
+			name = unsafeNameChars.ReplaceAllString(name, "_")
+			if name != "" && name != "." && name != "_" {
+				base = name
+			}
+		}
+		name := base
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[name] = true
+		names[uri] = name
+	}
+	return names
+}
+
+// bundleScope is a resource of the bundle which the references in it are
+// resolved against. The id is empty for the root document without $id.
+type bundleScope struct {
+	id      string
+	pointer string
+}
+
+// bundleTarget is the location of a resource in the bundle, and the URI
+// of the external document which has it.
+type bundleTarget struct {
+	pointer  string
+	scope    bundleScope
+	document string
+}
+
+type bundler struct {
+	p    *compilation
+	key  string
+	root *resource
+	uri  string
+	// ids is the external documents without $id which are given their URIs
+	// as $id, because they are referenced from the other resources.
+	ids map[string]bool
+
+	names   map[string]string
+	targets map[string]bundleTarget
+	missing map[string]bool
+	errs    DefinitionErrors
+}
+
+// build returns the bundle, and records the external documents which need
+// $id in missing.
+func (b *bundler) build() map[string]interface{} {
+	b.targets = map[string]bundleTarget{}
+	b.missing = map[string]bool{}
+	m := b.root.raw.(map[string]interface{})
+	defs, _ := m[b.key].(map[string]interface{})
+	if b.names == nil {
+		b.names = bundleNames(b.p.documents, defs)
+	}
+	_, _, id := b.p.enter(m, "#", b.uri, b.root.draft)
+	rootScope := bundleScope{id: strings.TrimSuffix(id, "#")}
+	b.index(m, "", b.uri, b.root.draft, rootScope, "")
+	for _, u := range b.p.documents {
+		r := b.p.resources[u]
+		pointer := "/" + EscapePointer(b.key) + "/" + EscapePointer(b.names[u])
+		scope := rootScope
+		if b.ids[u] {
+			scope = bundleScope{u, pointer}
+		}
+		b.index(r.raw, pointer, u, r.draft, scope, u)
+	}
+
+	bundle := b.rewrite(m, "#", b.uri, b.root.draft, rootScope, false).(map[string]interface{})
+	out := map[string]interface{}{}
+	for name := range defs {
+		out[name] = bundle[b.key].(map[string]interface{})[name]
+	}
+	for _, u := range b.p.documents {
+		r := b.p.resources[u]
+		pointer := "/" + EscapePointer(b.key) + "/" + EscapePointer(b.names[u])
+		doc := b.rewrite(r.raw, "#"+pointer, u, r.draft, b.targets[u].scope, true)
+		if m, ok := doc.(map[string]interface{}); ok && b.ids[u] {
+			if r.draft == Draft04 {
+				m["id"] = u
+			} else {
+				m["$id"] = u
+			}
+		}
+		out[b.names[u]] = doc
+	}
+	bundle[b.key] = out
+	return bundle
+}
+
+// index registers the resources in raw which is at pointer in the bundle.
+func (b *bundler) index(raw interface{}, pointer, base string, draft Draft, scope bundleScope, document string) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		bb, d, id := b.p.enter(v, "#"+pointer, base, draft)
+		if id != "" {
+			id = strings.TrimSuffix(id, "#")
+			scope = bundleScope{id, pointer}
+			b.targets[id] = bundleTarget{pointer, scope, document}
+		}
+		if _, ok := b.targets[base]; !ok {
+			b.targets[base] = bundleTarget{pointer, scope, document}
+		}
+		for key, child := range v {
+			switch key {
+			case "enum", "const", "default", "examples":
+				continue
+			}
+			b.index(child, pointer+"/"+EscapePointer(key), bb, d, scope, document)
+		}
+	case []interface{}:
+		for i, child := range v {
+			b.index(child, fmt.Sprintf("%s/%d", pointer, i), base, draft, scope, document)
+		}
+	}
+}
+
+// rewrite copies raw at location of the bundle, and rewrites the references
+// in it. The external reports whether raw is in an external document, whose
+// relative $ids are made absolute.
+func (b *bundler) rewrite(raw interface{}, location, base string, draft Draft, scope bundleScope, external bool) interface{} {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		bb, d, id := b.p.enter(v, location, base, draft)
+		if id != "" {
+			id = strings.TrimSuffix(id, "#")
+			scope = b.targets[id].scope
+		}
+		for key, child := range v {
+			switch key {
+			case "enum", "const", "default", "examples":
+				m[key] = child
+				continue
+			}
+			m[key] = b.rewrite(child, location+"/"+EscapePointer(key), bb, d, scope, external)
+		}
+		if id != "" && external {
+			key := "$id"
+			if d == Draft04 {
+				key = "id"
+			}
+			m[key] = id
+		}
+		if ref, ok := v["$ref"].(string); ok {
+			m["$ref"] = b.reference(ref, location, bb, scope)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, child := range v {
+			a[i] = b.rewrite(child, fmt.Sprintf("%s/%d", location, i), base, draft, scope, external)
+		}
+		return a
+	}
+	return raw
+}
+
+// reference returns ref in the scope of the bundle.
+func (b *bundler) reference(ref, location, base string, scope bundleScope) string {
+	uri, err := resolveURI(base, ref)
+	if err != nil {
+		return ref
+	}
+	u, fragment := splitFragment(uri)
+	t, ok := b.targets[u]
+	if !ok {
+		return ref
+	}
+	if fragment == "" || fragment[0] == '/' {
+		fragment = strings.TrimPrefix(t.pointer+fragment, t.scope.pointer)
+	}
+	f := (&url.URL{Fragment: fragment}).String()
+	switch {
+	case t.scope == scope:
+		return f
+	case t.scope.id != "":
+		return t.scope.id + f
+	case t.document != "":
+		b.missing[t.document] = true
+		return ref
+	}
+	b.errs = append(b.errs, &DefinitionError{Location: location + "/$ref", Keyword: "$ref", Err: UnbundlableReferenceError})
+	return ref
+}
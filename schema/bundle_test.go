@@ -0,0 +1,145 @@
+package schema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+// instanceErrors returns the instance paths and the keywords of the errors,
+// which don't depend on the locations of the schemas.
+func instanceErrors(err error) []string {
+	errs, _ := err.(schema.ValidationErrors)
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, e.InstancePath+" "+e.Keyword)
+	}
+	return actual
+}
+
+func TestBundleFile(t *testing.T) {
+	compiler := schema.NewCompiler()
+	compiler.Loader = schema.Loaders{
+		schema.DirLoader{"https://schemas.example.com/": "testdata/loader"},
+		schema.FileLoader{},
+	}
+	bundle, err := compiler.BundleFile("testdata/loader/order.json")
+	if err != nil {
+		t.Fatalf("Fail to BundleFile: %s", err)
+	}
+	expected, err := decodeInstance(readTestdata(t, "order.bundle.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bundle, expected) {
+		t.Errorf("expected %v, but actual %v", expected, bundle)
+	}
+
+	original, err := compiler.LoadFile("testdata/loader/order.json")
+	if err != nil {
+		t.Fatalf("Fail to LoadFile: %s", err)
+	}
+	bundled, err := schema.Compile(bundle)
+	if err != nil {
+		t.Fatalf("Fail to Compile the bundle: %s", err)
+	}
+	for _, instance := range []interface{}{
+		map[string]interface{}{"user": map[string]interface{}{"name": "abc"}},
+		map[string]interface{}{"user": map[string]interface{}{}},
+		map[string]interface{}{"user": map[string]interface{}{"name": "abcdef"}},
+		map[string]interface{}{"items": []interface{}{map[string]interface{}{"name": "abcdef"}, 1.0}},
+	} {
+		expected, actual := instanceErrors(original.Validate(instance)), instanceErrors(bundled.Validate(instance))
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Test with %v: expected %v, but actual %v", instance, expected, actual)
+		}
+	}
+}
+
+func TestBundle(t *testing.T) {
+	type Case struct {
+		Message   string
+		Documents schema.MemoryLoader
+		Expected  string
+		Error     error
+	}
+	cases := []Case{
+		{
+			Message: "no external documents",
+			Documents: schema.MemoryLoader{
+				"https://example.com/root.json": []byte(`{"$ref": "#/definitions/a", "definitions": {"a": {"type": "string"}}}`),
+			},
+			Expected: `{"$ref": "#/definitions/a", "definitions": {"a": {"type": "string"}}}`,
+		},
+		{
+			Message: "definitions of draft-07",
+			Documents: schema.MemoryLoader{
+				"https://example.com/root.json": []byte(`{
+					"$schema": "http://json-schema.org/draft-07/schema#",
+					"properties": {"a": {"$ref": "a.json#/definitions/b"}, "c": {"$ref": "#/definitions/c"}},
+					"definitions": {"c": {"type": "string"}, "a": {"type": "null"}}
+				}`),
+				"https://example.com/a.json": []byte(`{"definitions": {"b": {"$ref": "#/definitions/c"}, "c": {"type": "integer"}}}`),
+			},
+			Expected: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"properties": {"a": {"$ref": "#/definitions/a-2/definitions/b"}, "c": {"$ref": "#/definitions/c"}},
+				"definitions": {
+					"c": {"type": "string"},
+					"a": {"type": "null"},
+					"a-2": {"definitions": {"b": {"$ref": "#/definitions/a-2/definitions/c"}, "c": {"type": "integer"}}}
+				}
+			}`,
+		},
+		{
+			Message: "anchors and relative $ids",
+			Documents: schema.MemoryLoader{
+				"https://example.com/root.json": []byte(`{"$ref": "schemas/a.json#name"}`),
+				"https://example.com/schemas/a.json": []byte(`{
+					"$defs": {"name": {"$anchor": "name", "type": "string"}, "b": {"$id": "b.json", "type": "integer"}},
+					"properties": {"b": {"$ref": "b.json"}}
+				}`),
+			},
+			Expected: `{
+				"$ref": "#name",
+				"$defs": {
+					"a": {
+						"$defs": {"name": {"$anchor": "name", "type": "string"}, "b": {"$id": "https://example.com/schemas/b.json", "type": "integer"}},
+						"properties": {"b": {"$ref": "https://example.com/schemas/b.json"}}
+					}
+				}
+			}`,
+		},
+		{
+			Message: "reference to the root without $id",
+			Documents: schema.MemoryLoader{
+				"https://example.com/root.json": []byte(`{"$ref": "https://example.org/a.json", "$defs": {"b": {"type": "string"}}}`),
+				"https://example.org/a.json":    []byte(`{"$id": "https://example.org/a.json", "$ref": "https://example.com/root.json#/$defs/b"}`),
+			},
+			Error: &schema.DefinitionError{Location: "#/$defs/a/$ref", Keyword: "$ref", Err: schema.UnbundlableReferenceError},
+		},
+	}
+	for _, c := range cases {
+		compiler := schema.NewCompiler()
+		compiler.Loader = c.Documents
+		bundle, err := compiler.Bundle("https://example.com/root.json")
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected error %v, but actual %v", c.Message, c.Error, err)
+			continue
+		}
+		if c.Error != nil {
+			continue
+		}
+		expected, err := decodeInstance([]byte(c.Expected))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(bundle, expected) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, expected, bundle)
+		}
+		if _, err := schema.Compile(bundle); err != nil {
+			t.Errorf("Test with %s: fail to Compile the bundle: %s", c.Message, err)
+		}
+	}
+}
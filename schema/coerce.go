@@ -0,0 +1,169 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CoercionValidationError reports a string which can't be converted to the types of its schema.
+type CoercionValidationError struct {
+	Types []string `json:"types"`
+	Input string   `json:"input"`
+}
+
+func (err CoercionValidationError) Error() string {
+	return fmt.Sprintf("input value '%s' can't be converted to %v", err.Input, err.Types)
+}
+
+// Coerce returns the copy of instance in which the strings are converted to
+// the types of their schemas, for the values such as query parameters and
+// form fields which arrive as strings.
+//
+// The strings are converted to the first type of the type keyword which
+// they represent: "42" to an integer and "1.5" to a number as json.Number,
+// "true" and "false" to a boolean, "" and "null" to null, and a comma
+// separated string to an array whose items are converted in turn. The strings
+// are kept when the types include string or are absent. The type keyword is
+// also looked up through $ref and allOf.
+// The strings which can't be converted are reported as ValidationErrors.
+func (s *Schema) Coerce(instance interface{}) (interface{}, error) {
+	var errs ValidationErrors
+	v := s.coerce(instance, "", &errs)
+	if len(errs) > 0 {
+		return v, errs
+	}
+	return v, nil
+}
+
+// ValidateWithCoercion converts instance with Coerce, and validates it like
+// Validate. The converted instance is returned. The instance which can't be
+// converted is not validated, and the errors of Coerce are returned.
+func (s *Schema) ValidateWithCoercion(instance interface{}) (interface{}, error) {
+	v, err := s.Coerce(instance)
+	if err != nil {
+		return v, err
+	}
+	return v, s.Validate(v)
+}
+
+// types returns the type keyword of s, or of the schemas which s refers.
+func (s *Schema) types(v interface{}) ([]string, *Schema) {
+	for _, sub := range s.subschemas(v) {
+		if len(sub.Types) > 0 {
+			return sub.Types, sub
+		}
+	}
+	return nil, nil
+}
+
+func (s *Schema) coerce(v interface{}, path string, errs *ValidationErrors) interface{} {
+	switch t := v.(type) {
+	case string:
+		types, owner := s.types(v)
+		if len(types) == 0 || contains(types, "string") {
+			return t
+		}
+		for _, typ := range types {
+			if c, ok := s.coerceString(t, typ, path, errs); ok {
+				return c
+			}
+		}
+		*errs = append(*errs, &ValidationError{
+			InstancePath: path,
+			SchemaPath:   owner.Location + "/type",
+			Keyword:      "type",
+			Err:          &CoercionValidationError{types, t},
+		})
+		return t
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, item := range t {
+			a[i] = s.item(i).coerce(item, path+"/"+strconv.Itoa(i), errs)
+		}
+		return a
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		m := make(map[string]interface{}, len(t))
+		for _, key := range keys {
+			m[key] = s.property(key, t).coerce(t[key], path+"/"+EscapePointer(key), errs)
+		}
+		return m
+	}
+	return v
+}
+
+var (
+	jsonInteger = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+	jsonNumber  = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+)
+
+// coerceString converts str to the type typ. The ok reports whether str represents it.
+func (s *Schema) coerceString(str, typ, path string, errs *ValidationErrors) (interface{}, bool) {
+	switch typ {
+	case "integer":
+		if jsonInteger.MatchString(str) {
+			return json.Number(str), true
+		}
+	case "number":
+		// the numbers out of the range of float64 are rejected as the infinities
+		if _, err := strconv.ParseFloat(str, 64); err == nil && jsonNumber.MatchString(str) {
+			return json.Number(str), true
+		}
+	case "boolean":
+		if str == "true" || str == "false" {
+			return str == "true", true
+		}
+	case "null":
+		if str == "" || str == "null" {
+			return nil, true
+		}
+	case "array":
+		items := []interface{}{}
+		if str != "" {
+			for _, item := range strings.Split(str, ",") {
+				items = append(items, item)
+			}
+		}
+		return s.coerce(items, path, errs), true
+	}
+	return nil, false
+}
+
+// item returns the schema of the i-th item of the arrays, or the empty schema.
+func (s *Schema) item(i int) *Schema {
+	for _, sub := range s.subschemas(nil) {
+		if i < len(sub.PrefixItems) {
+			return sub.PrefixItems[i]
+		}
+		if sub.Items != nil {
+			return sub.Items
+		}
+	}
+	return &Schema{}
+}
+
+// property returns the schema of the property name of the object m, or the empty schema.
+func (s *Schema) property(name string, m map[string]interface{}) *Schema {
+	for _, sub := range s.subschemas(m) {
+		if p, ok := sub.Properties[name]; ok {
+			return p
+		}
+		for _, pattern := range sortedKeys(sub.PatternProperties) {
+			if sub.patternProperties[pattern].MatchString(name) {
+				return sub.PatternProperties[pattern]
+			}
+		}
+		if sub.AdditionalProperties != nil {
+			return sub.AdditionalProperties
+		}
+	}
+	return &Schema{}
+}
@@ -0,0 +1,117 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestValidateWithCoercion(t *testing.T) {
+	s, err := schema.Load([]byte(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"price": {"type": "number"},
+			"active": {"type": "boolean"},
+			"parent": {"type": ["integer", "null"]},
+			"name": {"type": ["string", "integer"]},
+			"tags": {"type": "array", "items": {"type": "integer"}, "maxItems": 3},
+			"sort": {"$ref": "#/$defs/order"},
+			"free": {}
+		},
+		"$defs": {"order": {"type": "array", "items": {"enum": ["asc", "desc"]}}}
+	}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance map[string]interface{}
+		Expected map[string]interface{}
+		Errors   []string
+	}
+	cases := []Case{
+		{
+			Message: "valid values",
+			Instance: map[string]interface{}{
+				"id": "42", "price": "1.5", "active": "true", "parent": "", "name": "7",
+				"tags": "1,2", "sort": []interface{}{"asc"}, "free": "1",
+			},
+			Expected: map[string]interface{}{
+				"id": json.Number("42"), "price": json.Number("1.5"), "active": true, "parent": nil, "name": "7",
+				"tags": []interface{}{json.Number("1"), json.Number("2")}, "sort": []interface{}{"asc"}, "free": "1",
+			},
+		},
+		{
+			Message:  "empty array",
+			Instance: map[string]interface{}{"tags": ""},
+			Expected: map[string]interface{}{"tags": []interface{}{}},
+		},
+		{
+			Message:  "values which can't be converted",
+			Instance: map[string]interface{}{"id": "1.5", "active": "yes", "tags": "1,a"},
+			Expected: map[string]interface{}{"id": "1.5", "active": "yes", "tags": []interface{}{json.Number("1"), "a"}},
+			Errors:   []string{"/active type", "/id type", "/tags/1 type"},
+		},
+		{
+			Message:  "numbers which aren't in the JSON grammar",
+			Instance: map[string]interface{}{"id": "+5", "price": "Inf", "parent": "007", "tags": "1_000,0x1p-2,NaN"},
+			Expected: map[string]interface{}{
+				"id": "+5", "price": "Inf", "parent": "007", "tags": []interface{}{"1_000", "0x1p-2", "NaN"},
+			},
+			Errors: []string{"/id type", "/parent type", "/price type", "/tags/0 type", "/tags/1 type", "/tags/2 type"},
+		},
+		{
+			Message:  "number out of the range of float64",
+			Instance: map[string]interface{}{"price": "1e400"},
+			Expected: map[string]interface{}{"price": "1e400"},
+			Errors:   []string{"/price type"},
+		},
+		{
+			Message:  "number with the exponent",
+			Instance: map[string]interface{}{"price": "-0.5e-3"},
+			Expected: map[string]interface{}{"price": json.Number("-0.5e-3")},
+		},
+		{
+			Message:  "converted values are validated",
+			Instance: map[string]interface{}{"id": "0", "tags": "1,2,3,4", "sort": "up"},
+			Expected: map[string]interface{}{
+				"id":   json.Number("0"),
+				"tags": []interface{}{json.Number("1"), json.Number("2"), json.Number("3"), json.Number("4")},
+				"sort": []interface{}{"up"},
+			},
+			Errors: []string{"/id minimum", "/sort/0 enum", "/tags maxItems"},
+		},
+	}
+	for _, c := range cases {
+		v, err := s.ValidateWithCoercion(c.Instance)
+		if !reflect.DeepEqual(v, c.Expected) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Expected, v)
+		}
+		var actual []string
+		if errs, ok := err.(schema.ValidationErrors); ok {
+			for _, e := range errs {
+				actual = append(actual, e.InstancePath+" "+e.Keyword)
+			}
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+
+	_, err = s.Coerce(map[string]interface{}{"id": "x"})
+	expected := schema.ValidationErrors{
+		{
+			InstancePath: "/id",
+			SchemaPath:   "#/properties/id/type",
+			Keyword:      "type",
+			Err:          &schema.CoercionValidationError{Types: []string{"integer"}, Input: "x"},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
@@ -0,0 +1,1094 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+var types = []string{"null", "boolean", "object", "array", "number", "string", "integer"}
+
+// Compiler compiles schema documents into Schema.
+type Compiler struct {
+	// Draft is the draft for documents which don't declare $schema.
+	Draft Draft
+	// Discriminator enables the discriminator keyword of OpenAPI next to
+	// oneOf and anyOf.
+	Discriminator bool
+	// Limits bounds ValidateContext of the compiled schemas.
+	Limits Limits
+	// Loader loads the documents which $ref references out of the compiled
+	// document. The references to them are unresolvable if it's nil,
+	// except the meta-schemas, which MetaSchemaLoader loads.
+	Loader Loader
+
+	keywords map[string]KeywordCompiler
+}
+
+func NewCompiler() *Compiler {
+	return &Compiler{Draft: DefaultDraft}
+}
+
+// Compile compiles doc with the default Compiler.
+func Compile(doc interface{}) (*Schema, error) {
+	return NewCompiler().Compile(doc)
+}
+
+// Load decodes data as JSON and compiles it with the default Compiler.
+func Load(data []byte) (*Schema, error) {
+	return NewCompiler().Load(data)
+}
+
+// Load decodes data as JSON and compiles it.
+func (c *Compiler) Load(data []byte) (*Schema, error) {
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.Compile(doc)
+}
+
+// Compile compiles doc which is a schema document decoded by encoding/json.
+// The draft of the document is decided by $schema, or by Draft of c if
+// $schema is absent. The first DefinitionError is returned when the
+// document is invalid; Lint reports all of them.
+func (c *Compiler) Compile(doc interface{}) (*Schema, error) {
+	s, p := c.compile(doc)
+	if len(p.errs) > 0 {
+		return nil, p.errs[0]
+	}
+	return s, nil
+}
+
+// compile compiles doc, and returns the compilation with its errors.
+func (c *Compiler) compile(doc interface{}) (*Schema, *compilation) {
+	p, root := c.begin(doc, "")
+	s := p.compile(doc, root.location, root.base, root.draft, true)
+	p.resolvePending()
+	return s, p
+}
+
+// CompileRefs compiles the subschemas of doc which refs reference, such as
+// "#/components/schemas/User", in a compilation, so that they share the
+// subschemas. The root of doc doesn't have to be a schema.
+func (c *Compiler) CompileRefs(doc interface{}, refs ...string) ([]*Schema, error) {
+	p, root := c.begin(doc, "")
+	ss := make([]*Schema, len(refs))
+	for i, ref := range refs {
+		ss[i] = &Schema{Location: ref, Draft: root.draft, Ref: ref}
+		p.addPending(ss[i], root.base)
+	}
+	p.resolvePending()
+	if len(p.errs) > 0 {
+		return nil, p.errs[0]
+	}
+	for i, s := range ss {
+		ss[i] = s.ref
+	}
+	return ss, nil
+}
+
+// begin scans doc whose URI is base, and returns the compilation and the
+// root resource.
+func (c *Compiler) begin(doc interface{}, base string) (*compilation, *resource) {
+	draft := c.Draft
+	if draft == 0 {
+		draft = DefaultDraft
+	}
+	p := &compilation{
+		schemas:       map[string]*Schema{},
+		raws:          map[string]interface{}{},
+		resources:     map[string]*resource{},
+		dynamic:       map[string]*resource{},
+		anchors:       map[string]*Schema{},
+		discriminator: c.Discriminator,
+		keywords:      c.keywords,
+		limits:        c.Limits,
+		loader:        c.Loader,
+	}
+	p.scan(doc, "#", base, draft)
+	root, ok := p.resources[""]
+	if !ok {
+		root = &resource{doc, "#", base, draft}
+		p.resources[""] = root
+	}
+	if _, ok := p.resources[base]; !ok && base != "" {
+		p.resources[base] = root
+	}
+	return p, root
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	return doc, nil
+}
+
+// resource is a schema which is identified by a URI.
+type resource struct {
+	raw      interface{}
+	location string
+	base     string
+	draft    Draft
+}
+
+type compilation struct {
+	schemas   map[string]*Schema
+	raws      map[string]interface{}
+	resources map[string]*resource
+	pending   []*reference
+	errs      DefinitionErrors
+	// dynamic is the resources of the dynamic anchors by base#anchor, and
+	// $recursiveAnchor is the anchor "". anchors is their schemas, which
+	// are compiled when the compilation has a dynamic reference.
+	dynamic     map[string]*resource
+	anchors     map[string]*Schema
+	dynamicRefs bool
+
+	discriminator bool
+	keywords      map[string]KeywordCompiler
+	limits        Limits
+	loader        Loader
+	// failures is the errors of the documents which loader failed to load.
+	failures map[string]error
+	// documents is the URIs of the documents which loader loaded in order.
+	documents []string
+}
+
+// fail records the error of the keyword at location.
+// The compilation goes on, so that all errors are reported.
+func (p *compilation) fail(location, keyword string, err error) {
+	if keyword != "" {
+		location += "/" + EscapePointer(keyword)
+	}
+	for _, e := range p.errs {
+		if e.Location == location && e.Err.Error() == err.Error() {
+			return
+		}
+	}
+	p.errs = append(p.errs, &DefinitionError{
+		Location: location,
+		Keyword:  keyword,
+		Err:      err,
+	})
+}
+
+// enter returns the base URI and the draft in the scope of the schema m.
+func (p *compilation) enter(m map[string]interface{}, location, base string, draft Draft) (string, Draft, string) {
+	if v, ok := m["$schema"]; ok {
+		if uri, ok := v.(string); ok {
+			d, err := DraftFromURI(uri)
+			if err != nil {
+				p.fail(location, "$schema", err)
+			} else {
+				draft = d
+			}
+		} else {
+			p.fail(location, "$schema", DefinitionTypeError)
+		}
+	}
+	if _, ok := m["$ref"]; ok && draft <= Draft07 {
+		return base, draft, ""
+	}
+	key := "$id"
+	if draft == Draft04 {
+		key = "id"
+	}
+	id, ok := m[key].(string)
+	if !ok {
+		return base, draft, ""
+	}
+	uri, err := resolveURI(base, id)
+	if err != nil {
+		p.fail(location, key, err)
+		return base, draft, ""
+	}
+	r, _ := splitFragment(uri)
+	return r, draft, uri
+}
+
+// schemaMaps are the keywords whose values are the maps of the schemas by
+// the names, which are not schemas themselves.
+var schemaMaps = map[string]bool{
+	"properties": true, "patternProperties": true, "dependentSchemas": true,
+	"dependencies": true, "$defs": true, "definitions": true,
+}
+
+// scan registers the resources and the anchors in raw.
+func (p *compilation) scan(raw interface{}, location, base string, draft Draft) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		b, d, id := p.enter(v, location, base, draft)
+		if location == "#" {
+			p.resources[""] = &resource{raw, location, b, d}
+		}
+		if id != "" {
+			p.resources[id] = &resource{raw, location, b, d}
+		}
+		if d >= Draft201909 {
+			for _, key := range []string{"$anchor", "$dynamicAnchor"} {
+				if anchor, ok := v[key].(string); ok {
+					p.resources[b+"#"+anchor] = &resource{raw, location, b, d}
+				}
+			}
+		}
+		if anchor, ok := v["$dynamicAnchor"].(string); ok && d >= Draft202012 {
+			p.dynamic[b+"#"+anchor] = &resource{raw, location, b, d}
+		}
+		if v["$recursiveAnchor"] == true && d == Draft201909 {
+			p.dynamic[b+"#"] = &resource{raw, location, b, d}
+		}
+		for key, child := range v {
+			switch key {
+			case "enum", "const", "default", "examples":
+				continue
+			}
+			if m, ok := child.(map[string]interface{}); ok && schemaMaps[key] {
+				for name, c := range m {
+					p.scan(c, location+"/"+EscapePointer(key)+"/"+EscapePointer(name), b, d)
+				}
+				continue
+			}
+			p.scan(child, location+"/"+EscapePointer(key), b, d)
+		}
+	case []interface{}:
+		for i, child := range v {
+			p.scan(child, location+"/"+strconv.Itoa(i), base, draft)
+		}
+	}
+}
+
+// compile compiles raw at location, or returns the schema already compiled at location.
+// The allowBool reports whether raw may be a boolean schema in draft-04.
+func (p *compilation) compile(raw interface{}, location, base string, draft Draft, allowBool bool) *Schema {
+	if s, ok := p.schemas[location]; ok {
+		return s
+	}
+	s := &Schema{
+		Location: location,
+		Draft:    draft,
+		limits:   p.limits,
+		base:     base,
+		anchors:  p.anchors,
+	}
+	p.schemas[location] = s
+	p.raws[location] = raw
+
+	switch v := raw.(type) {
+	case bool:
+		if draft == Draft04 && !allowBool {
+			p.fail(location, "", DefinitionTypeError)
+		}
+		s.Boolean = &v
+		return s
+	case map[string]interface{}:
+		base, s.Draft, s.ID = p.enter(v, location, base, draft)
+		s.base = base
+		k := &keywords{p: p, s: s, m: v, base: base, seen: map[string]bool{}}
+		k.parse()
+		k.build()
+		return s
+	default:
+		p.fail(location, "", DefinitionTypeError)
+		return s
+	}
+}
+
+// resolvePending resolves the pending references, which may add more, and
+// compiles the dynamic anchors if the compilation has a dynamic reference.
+func (p *compilation) resolvePending() {
+	for {
+		for len(p.pending) > 0 {
+			r := p.pending[0]
+			p.pending = p.pending[1:]
+			p.resolve(r)
+		}
+		if !p.dynamicRefs || len(p.anchors) == len(p.dynamic) {
+			p.checkCycles()
+			return
+		}
+		for key, r := range p.dynamic {
+			if _, ok := p.anchors[key]; !ok {
+				p.anchors[key] = p.compile(r.raw, r.location, r.base, r.draft, true)
+			}
+		}
+	}
+}
+
+// checkCycles reports the references which lead back to the same schema
+// only through the in-place applicators such as $ref and allOf, without a
+// property or an item in between, since their evaluation would never end.
+func (p *compilation) checkCycles() {
+	const visiting, visited = 1, 2
+	states := map[*Schema]int{}
+	var visit func(s *Schema)
+	visit = func(s *Schema) {
+		states[s] = visiting
+		for _, a := range p.inPlace(s) {
+			switch states[a.s] {
+			case 0:
+				visit(a.s)
+			case visiting:
+				p.fail(s.Location, a.keyword, ReferenceCycleError)
+			}
+		}
+		states[s] = visited
+	}
+	locations := make([]string, 0, len(p.schemas))
+	for location := range p.schemas {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	for _, location := range locations {
+		if s := p.schemas[location]; states[s] == 0 {
+			visit(s)
+		}
+	}
+}
+
+// applicator is a subschema which applies to the same value as its parent,
+// by the keyword of the parent.
+type applicator struct {
+	keyword string
+	s       *Schema
+}
+
+// inPlace returns the in-place applicators of s. The dynamic references
+// may lead to any schema with the same dynamic anchor.
+func (p *compilation) inPlace(s *Schema) []applicator {
+	var as []applicator
+	add := func(keyword string, ss ...*Schema) {
+		for _, sub := range ss {
+			if sub != nil {
+				as = append(as, applicator{keyword, sub})
+			}
+		}
+	}
+	add("$ref", s.ref)
+	if s.dynamicRef != nil {
+		keyword, anchor := "$dynamicRef", ""
+		if s.Draft == Draft201909 {
+			keyword = "$recursiveRef"
+		} else {
+			_, anchor = splitFragment(s.DynamicRef)
+		}
+		add(keyword, s.dynamicRef)
+		for _, key := range sortedKeys(p.anchors) {
+			if _, a := splitFragment(key); a == anchor && (anchor != "" || s.Draft == Draft201909) {
+				add(keyword, p.anchors[key])
+			}
+		}
+	}
+	add("allOf", s.AllOf...)
+	add("anyOf", s.AnyOf...)
+	add("oneOf", s.OneOf...)
+	add("not", s.Not)
+	add("if", s.If)
+	add("then", s.Then)
+	add("else", s.Else)
+	for _, name := range sortedKeys(s.DependentSchemas) {
+		add("dependentSchemas", s.DependentSchemas[name])
+	}
+	if d := s.Discriminator; d != nil {
+		for _, value := range d.values() {
+			add("discriminator", d.Mapping[value])
+		}
+	}
+	return as
+}
+
+// reference is a pending reference of the keyword of s such as $ref, which
+// is resolved into target after the documents are scanned.
+type reference struct {
+	s       *Schema
+	keyword string
+	ref     string
+	base    string
+	target  **Schema
+}
+
+// addPending adds $ref of s, which is resolved against base.
+func (p *compilation) addPending(s *Schema, base string) {
+	p.pending = append(p.pending, &reference{s, "$ref", s.Ref, base, &s.ref})
+}
+
+// resolve resolves ref into its target.
+func (p *compilation) resolve(ref *reference) {
+	s := ref.s
+	uri, err := resolveURI(ref.base, ref.ref)
+	if err != nil {
+		p.fail(s.Location, ref.keyword, err)
+		return
+	}
+	base, fragment := splitFragment(uri)
+	if _, ok := p.resources[uri]; !ok && base != "" {
+		if _, ok := p.resources[base]; !ok {
+			err := p.load(base, s.Draft)
+			if err != nil && (p.loader != nil || !errors.Is(err, UnsupportedURIError)) {
+				p.fail(s.Location, ref.keyword, err)
+				return
+			}
+		}
+	}
+	if r, ok := p.resources[uri]; ok {
+		*ref.target = p.compile(r.raw, r.location, r.base, r.draft, true)
+		return
+	}
+	r, ok := p.resources[base]
+	if !ok || (fragment != "" && fragment[0] != '/') {
+		p.fail(s.Location, ref.keyword, UnresolvableReferenceError)
+		return
+	}
+	raw, location, b, d := r.raw, r.location, r.base, r.draft
+	if fragment != "" {
+		names := false
+		for _, token := range splitPointer(fragment) {
+			if m, ok := raw.(map[string]interface{}); ok && !names {
+				b, d, _ = p.enter(m, location, b, d)
+			}
+			names = !names && schemaMaps[token]
+			var ok bool
+			raw, ok = child(raw, token)
+			if !ok {
+				p.fail(s.Location, ref.keyword, UnresolvableReferenceError)
+				return
+			}
+			location += "/" + EscapePointer(token)
+		}
+	}
+	*ref.target = p.compile(raw, location, b, d, true)
+}
+
+// keywords parses the keywords of a schema object into its Schema.
+type keywords struct {
+	p    *compilation
+	s    *Schema
+	m    map[string]interface{}
+	base string
+	seen map[string]bool
+}
+
+func (k *keywords) get(key string) (interface{}, bool) {
+	v, ok := k.m[key]
+	if ok {
+		k.seen[key] = true
+	}
+	return v, ok
+}
+
+func (k *keywords) fail(key string, err error) {
+	k.p.fail(k.s.Location, key, err)
+}
+
+func (k *keywords) location(keys ...string) string {
+	l := k.s.Location
+	for _, key := range keys {
+		l += "/" + EscapePointer(key)
+	}
+	return l
+}
+
+func (k *keywords) schema(key string) *Schema {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	allowBool := key == "additionalProperties" || key == "additionalItems"
+	return k.p.compile(v, k.location(key), k.base, k.s.Draft, allowBool)
+}
+
+func (k *keywords) schemaArray(key string) []*Schema {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	a, ok := v.([]interface{})
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+		return nil
+	}
+	if len(a) == 0 {
+		k.fail(key, DefinitionEmptyError)
+		return nil
+	}
+	ss := make([]*Schema, len(a))
+	for i, e := range a {
+		ss[i] = k.p.compile(e, k.location(key, strconv.Itoa(i)), k.base, k.s.Draft, false)
+	}
+	return ss
+}
+
+func (k *keywords) schemaMap(key string) map[string]*Schema {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+		return nil
+	}
+	ss := make(map[string]*Schema, len(m))
+	for name, e := range m {
+		ss[name] = k.p.compile(e, k.location(key, name), k.base, k.s.Draft, false)
+	}
+	return ss
+}
+
+func (k *keywords) number(key string) *float64 {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+		return nil
+	}
+	return &f
+}
+
+// literal returns the number of the keyword key as it is written, so that
+// the numbers which are not floats are compared with it exactly.
+func (k *keywords) literal(key string) json.Number {
+	switch n := k.m[key].(type) {
+	case json.Number:
+		return n
+	case float64:
+		return json.Number(strconv.FormatFloat(n, 'g', -1, 64))
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(n), 'g', -1, 32))
+	}
+	return json.Number(fmt.Sprint(k.m[key]))
+}
+
+// exclusiveLiteral returns the literal of the exclusive keyword key, which
+// is the boolean of the limit in draft-04.
+func (k *keywords) exclusiveLiteral(key, limit string) json.Number {
+	if k.s.Draft > Draft04 {
+		return k.literal(key)
+	}
+	return k.literal(limit)
+}
+
+func (k *keywords) integer(key string) *int {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	i, ok := toInt(v)
+	if !ok {
+		k.fail(key, DefinitionNonNegativeIntegerError)
+		return nil
+	}
+	return &i
+}
+
+func (k *keywords) nonNegativeInteger(key string) *int {
+	i := k.integer(key)
+	if i != nil && *i < 0 {
+		k.fail(key, DefinitionNonNegativeIntegerError)
+		return nil
+	}
+	return i
+}
+
+func (k *keywords) string(key string) string {
+	v, ok := k.get(key)
+	if !ok {
+		return ""
+	}
+	s, ok := v.(string)
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+	}
+	return s
+}
+
+func (k *keywords) stringArray(key string) []string {
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	return k.toStringArray(key, v)
+}
+
+func (k *keywords) toStringArray(key string, v interface{}) []string {
+	a, ok := v.([]interface{})
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+		return nil
+	}
+	ss := make([]string, len(a))
+	for i, e := range a {
+		s, ok := e.(string)
+		if !ok {
+			k.fail(key, DefinitionTypeError)
+			return nil
+		}
+		for _, t := range ss[:i] {
+			if t == s {
+				k.fail(key, DefinitionDuplicationError)
+				return nil
+			}
+		}
+		ss[i] = s
+	}
+	return ss
+}
+
+// exclusive parses the exclusive keyword key of the limit.
+// In draft-04 the keyword is a boolean which makes the limit exclusive,
+// and in the later drafts it is a number which is the exclusive limit itself.
+func (k *keywords) exclusive(key string, limit **float64) *float64 {
+	if k.s.Draft > Draft04 {
+		return k.number(key)
+	}
+	v, ok := k.get(key)
+	if !ok {
+		return nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		k.fail(key, DefinitionTypeError)
+		return nil
+	}
+	if !b {
+		return nil
+	}
+	if *limit == nil {
+		k.fail(key, DefinitionExclusiveNoLimitError)
+		return nil
+	}
+	l := *limit
+	*limit = nil
+	return l
+}
+
+func (k *keywords) parse() {
+	s := k.s
+	draft := s.Draft
+	for _, key := range []string{"$schema", "$id", "id", "$comment", "$anchor", "$dynamicAnchor", "$vocabulary"} {
+		k.seen[key] = true
+	}
+	if draft == Draft04 {
+		k.seen["$id"] = false
+	} else {
+		k.seen["id"] = false
+	}
+
+	if _, ok := k.m["$ref"]; ok {
+		s.Ref = k.string("$ref")
+		if draft <= Draft07 {
+			k.seen = map[string]bool{"$ref": true}
+			k.extra()
+			k.p.addPending(s, k.base)
+			return
+		}
+		k.p.addPending(s, k.base)
+	}
+	keyword := "$dynamicRef"
+	switch draft {
+	case Draft201909:
+		keyword = "$recursiveRef"
+		if v, ok := k.get("$recursiveAnchor"); ok {
+			b, ok := v.(bool)
+			if !ok {
+				k.fail("$recursiveAnchor", DefinitionTypeError)
+			}
+			s.RecursiveAnchor = b
+		}
+	case Draft202012:
+		s.DynamicAnchor = k.string("$dynamicAnchor")
+	}
+	if draft >= Draft201909 {
+		if s.DynamicRef = k.string(keyword); s.DynamicRef != "" {
+			k.p.dynamicRefs = true
+			k.p.pending = append(k.p.pending, &reference{s, keyword, s.DynamicRef, k.base, &s.dynamicRef})
+		}
+	}
+
+	if v, ok := k.get("type"); ok {
+		switch t := v.(type) {
+		case string:
+			s.Types = []string{t}
+		default:
+			s.Types = k.toStringArray("type", v)
+		}
+		for _, t := range s.Types {
+			if !contains(types, t) {
+				k.fail("type", DefinitionUnknownTypeError)
+			}
+		}
+	}
+	if v, ok := k.get("enum"); ok {
+		a, ok := v.([]interface{})
+		switch {
+		case !ok:
+			k.fail("enum", DefinitionTypeError)
+		case len(a) == 0:
+			k.fail("enum", DefinitionEmptyError)
+		default:
+			for i := range a {
+				for j := i + 1; j < len(a); j++ {
+					if equal(a[i], a[j]) {
+						k.fail("enum", DefinitionDuplicationError)
+					}
+				}
+			}
+			s.Enum = a
+		}
+	}
+	if draft >= Draft06 {
+		s.Const, s.HasConst = k.get("const")
+	}
+
+	s.MultipleOf = k.number("multipleOf")
+	if s.MultipleOf != nil && *s.MultipleOf <= 0 {
+		k.fail("multipleOf", DefinitionNotPositiveError)
+	}
+	s.Maximum = k.number("maximum")
+	s.ExclusiveMaximum = k.exclusive("exclusiveMaximum", &s.Maximum)
+	s.Minimum = k.number("minimum")
+	s.ExclusiveMinimum = k.exclusive("exclusiveMinimum", &s.Minimum)
+
+	s.MaxLength = k.integer("maxLength")
+	s.MinLength = k.integer("minLength")
+	s.Pattern = k.string("pattern")
+	s.Format = k.string("format")
+
+	if draft >= Draft202012 {
+		s.PrefixItems = k.schemaArray("prefixItems")
+		if v, ok := k.m["items"]; ok {
+			if _, ok := v.([]interface{}); ok {
+				k.fail("items", DefinitionTypeError)
+			}
+			s.Items = k.schema("items")
+		}
+	} else if v, ok := k.m["items"]; ok {
+		if _, ok := v.([]interface{}); ok {
+			s.PrefixItems = k.schemaArray("items")
+			s.Items = k.schema("additionalItems")
+		} else {
+			s.Items = k.schema("items")
+			k.seen["additionalItems"] = true
+		}
+	}
+	if draft >= Draft06 {
+		s.Contains = k.schema("contains")
+	}
+	if draft >= Draft201909 {
+		s.MaxContains = k.nonNegativeInteger("maxContains")
+		s.MinContains = k.nonNegativeInteger("minContains")
+	}
+	s.MaxItems = k.integer("maxItems")
+	s.MinItems = k.integer("minItems")
+	if v, ok := k.get("uniqueItems"); ok {
+		b, ok := v.(bool)
+		if !ok {
+			k.fail("uniqueItems", DefinitionTypeError)
+		}
+		s.UniqueItems = b
+	}
+	if draft >= Draft201909 {
+		s.UnevaluatedItems = k.schema("unevaluatedItems")
+	}
+
+	s.Properties = k.schemaMap("properties")
+	s.PatternProperties = k.schemaMap("patternProperties")
+	s.AdditionalProperties = k.schema("additionalProperties")
+	if draft >= Draft06 {
+		s.PropertyNames = k.schema("propertyNames")
+	}
+	s.Required = k.stringArray("required")
+	if draft == Draft04 && s.Required != nil && len(s.Required) == 0 {
+		k.fail("required", DefinitionEmptyError)
+	}
+	if draft <= Draft07 {
+		k.dependencies()
+	} else {
+		if v, ok := k.get("dependentRequired"); ok {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				k.fail("dependentRequired", DefinitionTypeError)
+			}
+			s.DependentRequired = make(map[string][]string, len(m))
+			for name, e := range m {
+				s.DependentRequired[name] = k.toStringArray("dependentRequired", e)
+			}
+		}
+		s.DependentSchemas = k.schemaMap("dependentSchemas")
+	}
+	s.MaxProperties = k.nonNegativeInteger("maxProperties")
+	s.MinProperties = k.nonNegativeInteger("minProperties")
+	if draft >= Draft201909 {
+		s.UnevaluatedProperties = k.schema("unevaluatedProperties")
+	}
+
+	s.AllOf = k.schemaArray("allOf")
+	s.AnyOf = k.schemaArray("anyOf")
+	s.OneOf = k.schemaArray("oneOf")
+	if k.p.discriminator && (s.OneOf != nil || s.AnyOf != nil) {
+		k.discriminator()
+	}
+	s.Not = k.schema("not")
+	if draft >= Draft07 {
+		s.If = k.schema("if")
+		s.Then = k.schema("then")
+		s.Else = k.schema("else")
+	}
+
+	s.Defs = k.schemaMap("definitions")
+	if draft >= Draft201909 {
+		for name, d := range k.schemaMap("$defs") {
+			if s.Defs == nil {
+				s.Defs = map[string]*Schema{}
+			}
+			s.Defs[name] = d
+		}
+	}
+
+	s.Title = k.string("title")
+	s.Description = k.string("description")
+	s.Default, s.HasDefault = k.get("default")
+	k.errorMessage()
+	k.customKeywords()
+	k.extra()
+}
+
+// dependencies splits the draft-04 to draft-07 dependencies keyword.
+func (k *keywords) dependencies() {
+	v, ok := k.get("dependencies")
+	if !ok {
+		return
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		k.fail("dependencies", DefinitionTypeError)
+		return
+	}
+	s := k.s
+	for name, e := range m {
+		if _, ok := e.([]interface{}); ok {
+			if s.DependentRequired == nil {
+				s.DependentRequired = map[string][]string{}
+			}
+			s.DependentRequired[name] = k.toStringArray("dependencies", e)
+			continue
+		}
+		if s.DependentSchemas == nil {
+			s.DependentSchemas = map[string]*Schema{}
+		}
+		s.DependentSchemas[name] = k.p.compile(e, k.location("dependencies", name), k.base, s.Draft, false)
+	}
+}
+
+// discriminator parses the discriminator of OpenAPI for the branches of
+// oneOf, or anyOf if oneOf is absent. The values of the mapping are the
+// references, or the names of the schemas in components/schemas.
+func (k *keywords) discriminator() {
+	v, ok := k.get("discriminator")
+	if !ok {
+		return
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		k.fail("discriminator", DefinitionTypeError)
+		return
+	}
+	name, ok := m["propertyName"].(string)
+	if !ok {
+		k.fail("discriminator", DefinitionDiscriminatorPropertyNameError)
+		return
+	}
+	s := k.s
+	d := &Discriminator{PropertyName: name, Mapping: map[string]*Schema{}}
+	branches := s.OneOf
+	if branches == nil {
+		branches, d.anyOf = s.AnyOf, true
+	}
+	for _, b := range branches {
+		if b.Ref != "" {
+			d.Mapping[refName(b.Ref)] = b
+		}
+	}
+	mapping, ok := m["mapping"].(map[string]interface{})
+	if _, exists := m["mapping"]; exists && !ok {
+		k.fail("discriminator", DefinitionTypeError)
+		return
+	}
+	for value, e := range mapping {
+		ref, ok := e.(string)
+		if !ok {
+			k.fail("discriminator", DefinitionTypeError)
+			return
+		}
+		d.Mapping[value] = k.mappedBranch(branches, value, ref)
+	}
+	s.Discriminator = d
+}
+
+// mappedBranch returns the branch which ref of the mapping refers to, or
+// the schema which references ref if it is not a branch.
+func (k *keywords) mappedBranch(branches []*Schema, value, ref string) *Schema {
+	name := !isReference(ref)
+	for _, b := range branches {
+		if b.Ref == ref || (name && b.Ref != "" && refName(b.Ref) == ref) {
+			return b
+		}
+	}
+	if name {
+		ref = "#/components/schemas/" + EscapePointer(ref)
+	}
+	b := &Schema{Location: k.location("discriminator", "mapping", value), Draft: k.s.Draft, Ref: ref}
+	k.p.addPending(b, k.base)
+	return b
+}
+
+func (k *keywords) extra() {
+	for key, v := range k.m {
+		if k.seen[key] {
+			continue
+		}
+		if k.s.Extra == nil {
+			k.s.Extra = map[string]interface{}{}
+		}
+		k.s.Extra[key] = v
+	}
+}
+
+// build constructs the validators for the keywords of the schema.
+func (k *keywords) build() {
+	s := k.s
+	if s.MaxLength != nil {
+		v, err := strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: *s.MaxLength})
+		if err != nil {
+			k.fail("maxLength", err)
+		}
+		s.maxLength = &v
+	}
+	if s.MinLength != nil {
+		v, err := strings.NewMinLengthValidator(strings.MinLengthValidatorDefinition{MinLength: *s.MinLength})
+		if err != nil {
+			k.fail("minLength", err)
+		}
+		s.minLength = &v
+	}
+	if s.Pattern != "" {
+		v, err := strings.NewPatternValidator(strings.PatternValidatorDefinition{Pattern: s.Pattern})
+		if err != nil {
+			k.fail("pattern", err)
+		}
+		s.pattern = &v
+	}
+	if s.Format != "" {
+		// unknown formats are annotations only
+		v, err := strings.NewFormatValidator(strings.FormatValidatorDefinition{Format: s.Format})
+		if err == nil {
+			s.format = &v
+		}
+	}
+	if s.MultipleOf != nil {
+		if v, err := bignumbers.NewMultipleOfValidator(bignumbers.MultipleOfValidatorDefinition{MultipleOf: k.literal("multipleOf")}); err == nil {
+			s.exactMultipleOf = &v
+		}
+	}
+	if s.Maximum != nil {
+		v, _ := numbers.NewMaximumValidator(numbers.MaximumValidatorDefinition{Maximum: *s.Maximum})
+		s.maximum = append(s.maximum, v)
+		e, _ := bignumbers.NewMaximumValidator(bignumbers.MaximumValidatorDefinition{Maximum: k.literal("maximum")})
+		s.exactMaximum = append(s.exactMaximum, e)
+	}
+	if s.ExclusiveMaximum != nil {
+		v, _ := numbers.NewMaximumValidator(numbers.MaximumValidatorDefinition{Maximum: *s.ExclusiveMaximum, Exclusive: true})
+		s.maximum = append(s.maximum, v)
+		e, _ := bignumbers.NewMaximumValidator(bignumbers.MaximumValidatorDefinition{Maximum: k.exclusiveLiteral("exclusiveMaximum", "maximum"), Exclusive: true})
+		s.exactMaximum = append(s.exactMaximum, e)
+	}
+	if s.Minimum != nil {
+		v, _ := numbers.NewMinimumValidator(numbers.MinimumValidatorDefinition{Minimum: *s.Minimum})
+		s.minimum = append(s.minimum, v)
+		e, _ := bignumbers.NewMinimumValidator(bignumbers.MinimumValidatorDefinition{Minimum: k.literal("minimum")})
+		s.exactMinimum = append(s.exactMinimum, e)
+	}
+	if s.ExclusiveMinimum != nil {
+		v, _ := numbers.NewMinimumValidator(numbers.MinimumValidatorDefinition{Minimum: *s.ExclusiveMinimum, Exclusive: true})
+		s.minimum = append(s.minimum, v)
+		e, _ := bignumbers.NewMinimumValidator(bignumbers.MinimumValidatorDefinition{Minimum: k.exclusiveLiteral("exclusiveMinimum", "minimum"), Exclusive: true})
+		s.exactMinimum = append(s.exactMinimum, e)
+	}
+	if s.MaxItems != nil {
+		v, err := arrays.NewMaxItemsValidator(arrays.MaxItemsValidatorDefinition{MaxItems: *s.MaxItems})
+		if err != nil {
+			k.fail("maxItems", err)
+		}
+		s.maxItems = &v
+	}
+	if s.MinItems != nil {
+		v, err := arrays.NewMinItemsValidator(arrays.MinItemsValidatorDefinition{MinItems: *s.MinItems})
+		if err != nil {
+			k.fail("minItems", err)
+		}
+		s.minItems = &v
+	}
+	if len(s.PatternProperties) > 0 {
+		s.patternProperties = make(map[string]*regexp.Regexp, len(s.PatternProperties))
+		for _, pattern := range sortedKeys(s.PatternProperties) {
+			if _, err := strings.NewPatternValidator(strings.PatternValidatorDefinition{Pattern: pattern}); err != nil && pattern != "" {
+				k.p.fail(k.location("patternProperties"), pattern, err)
+				continue
+			}
+			s.patternProperties[pattern] = regexp.MustCompile(pattern)
+		}
+	}
+}
+
+func child(raw interface{}, token string) (interface{}, bool) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		c, ok := v[token]
+		return c, ok
+	case []interface{}:
+		i, ok := toInt(json.Number(token))
+		if !ok || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		return v[i], true
+	}
+	return nil, false
+}
+
+func contains(ss []string, s string) bool {
+	for _, t := range ss {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]*Schema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
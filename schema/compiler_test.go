@@ -0,0 +1,326 @@
+package schema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+func TestLoadWithDraft(t *testing.T) {
+	type Case struct {
+		Message  string
+		Draft    schema.Draft
+		Document string
+		Expected schema.Draft
+	}
+	cases := []Case{
+		{
+			Message:  "default draft",
+			Document: `{}`,
+			Expected: schema.DefaultDraft,
+		},
+		{
+			Message:  "draft of compiler",
+			Draft:    schema.Draft04,
+			Document: `{}`,
+			Expected: schema.Draft04,
+		},
+		{
+			Message:  "$schema overrides draft of compiler",
+			Draft:    schema.Draft04,
+			Document: `{"$schema": "http://json-schema.org/draft-07/schema#"}`,
+			Expected: schema.Draft07,
+		},
+	}
+	for _, c := range cases {
+		compiler := schema.NewCompiler()
+		if c.Draft != 0 {
+			compiler.Draft = c.Draft
+		}
+		s, err := compiler.Load([]byte(c.Document))
+		if err != nil {
+			t.Errorf("Test with %s: fail to Load with error %v", c.Message, err)
+			continue
+		}
+		if s.Draft != c.Expected {
+			t.Errorf("Test with %s: expected %s, but actual %s", c.Message, c.Expected, s.Draft)
+		}
+	}
+}
+
+func TestLoadWithExclusiveLimits(t *testing.T) {
+	type Case struct {
+		Message          string
+		Document         string
+		Maximum          *float64
+		ExclusiveMaximum *float64
+		Minimum          *float64
+		ExclusiveMinimum *float64
+	}
+	ten, zero := 10.0, 0.0
+	cases := []Case{
+		{
+			Message:          "boolean exclusive in draft-04",
+			Document:         `{"$schema": "http://json-schema.org/draft-04/schema#", "maximum": 10, "exclusiveMaximum": true, "minimum": 0, "exclusiveMinimum": true}`,
+			ExclusiveMaximum: &ten,
+			ExclusiveMinimum: &zero,
+		},
+		{
+			Message:  "false exclusive in draft-04",
+			Document: `{"$schema": "http://json-schema.org/draft-04/schema#", "maximum": 10, "exclusiveMaximum": false, "minimum": 0}`,
+			Maximum:  &ten,
+			Minimum:  &zero,
+		},
+		{
+			Message:          "numeric exclusive in draft-06",
+			Document:         `{"$schema": "http://json-schema.org/draft-06/schema#", "exclusiveMaximum": 10, "minimum": 0}`,
+			ExclusiveMaximum: &ten,
+			Minimum:          &zero,
+		},
+		{
+			Message:          "numeric exclusive in 2020-12",
+			Document:         `{"maximum": 10, "exclusiveMaximum": 10, "exclusiveMinimum": 0}`,
+			Maximum:          &ten,
+			ExclusiveMaximum: &ten,
+			ExclusiveMinimum: &zero,
+		},
+	}
+	for _, c := range cases {
+		s, err := schema.Load([]byte(c.Document))
+		if err != nil {
+			t.Errorf("Test with %s: fail to Load with error %v", c.Message, err)
+			continue
+		}
+		if !reflect.DeepEqual(s.Maximum, c.Maximum) || !reflect.DeepEqual(s.ExclusiveMaximum, c.ExclusiveMaximum) ||
+			!reflect.DeepEqual(s.Minimum, c.Minimum) || !reflect.DeepEqual(s.ExclusiveMinimum, c.ExclusiveMinimum) {
+			t.Errorf("Test with %s: unexpected limits %v %v %v %v", c.Message, s.Maximum, s.ExclusiveMaximum, s.Minimum, s.ExclusiveMinimum)
+		}
+	}
+}
+
+func TestLoadWithItems(t *testing.T) {
+	type Case struct {
+		Message     string
+		Document    string
+		PrefixItems int
+		Items       bool
+		Extra       []string
+	}
+	cases := []Case{
+		{
+			Message:     "items array and additionalItems in draft-07",
+			Document:    `{"$schema": "http://json-schema.org/draft-07/schema#", "items": [{}, {}], "additionalItems": false}`,
+			PrefixItems: 2,
+			Items:       true,
+		},
+		{
+			Message:  "items schema and ignored additionalItems in draft-07",
+			Document: `{"$schema": "http://json-schema.org/draft-07/schema#", "items": {}, "additionalItems": false}`,
+			Items:    true,
+		},
+		{
+			Message:  "prefixItems in draft-07 is unknown",
+			Document: `{"$schema": "http://json-schema.org/draft-07/schema#", "prefixItems": [{}]}`,
+			Extra:    []string{"prefixItems"},
+		},
+		{
+			Message:     "prefixItems and items in 2020-12",
+			Document:    `{"prefixItems": [{}], "items": false}`,
+			PrefixItems: 1,
+			Items:       true,
+		},
+		{
+			Message:  "additionalItems in 2020-12 is unknown",
+			Document: `{"additionalItems": false}`,
+			Extra:    []string{"additionalItems"},
+		},
+	}
+	for _, c := range cases {
+		s, err := schema.Load([]byte(c.Document))
+		if err != nil {
+			t.Errorf("Test with %s: fail to Load with error %v", c.Message, err)
+			continue
+		}
+		if len(s.PrefixItems) != c.PrefixItems {
+			t.Errorf("Test with %s: expected %d prefix items, but actual %d", c.Message, c.PrefixItems, len(s.PrefixItems))
+		}
+		if (s.Items != nil) != c.Items {
+			t.Errorf("Test with %s: expected items %t, but actual %v", c.Message, c.Items, s.Items)
+		}
+		for _, key := range c.Extra {
+			if _, ok := s.Extra[key]; !ok {
+				t.Errorf("Test with %s: expected %s in extra keywords", c.Message, key)
+			}
+		}
+	}
+}
+
+func TestLoadWithDependencies(t *testing.T) {
+	type Case struct {
+		Message           string
+		Document          string
+		DependentRequired map[string][]string
+		DependentSchemas  []string
+	}
+	cases := []Case{
+		{
+			Message:           "dependencies in draft-07",
+			Document:          `{"$schema": "http://json-schema.org/draft-07/schema#", "dependencies": {"a": ["b"], "c": {"required": ["d"]}}}`,
+			DependentRequired: map[string][]string{"a": {"b"}},
+			DependentSchemas:  []string{"c"},
+		},
+		{
+			Message:           "dependentRequired and dependentSchemas in 2019-09",
+			Document:          `{"$schema": "https://json-schema.org/draft/2019-09/schema", "dependentRequired": {"a": ["b"]}, "dependentSchemas": {"c": {}}}`,
+			DependentRequired: map[string][]string{"a": {"b"}},
+			DependentSchemas:  []string{"c"},
+		},
+		{
+			Message:  "dependencies in 2020-12 is unknown",
+			Document: `{"dependencies": {"a": ["b"]}}`,
+		},
+	}
+	for _, c := range cases {
+		s, err := schema.Load([]byte(c.Document))
+		if err != nil {
+			t.Errorf("Test with %s: fail to Load with error %v", c.Message, err)
+			continue
+		}
+		if !reflect.DeepEqual(s.DependentRequired, c.DependentRequired) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.DependentRequired, s.DependentRequired)
+		}
+		if len(s.DependentSchemas) != len(c.DependentSchemas) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.DependentSchemas, s.DependentSchemas)
+		}
+		for _, name := range c.DependentSchemas {
+			if _, ok := s.DependentSchemas[name]; !ok {
+				t.Errorf("Test with %s: expected dependent schema %s", c.Message, name)
+			}
+		}
+	}
+}
+
+func TestLoadWithInvalidDefinition(t *testing.T) {
+	type Case struct {
+		Message  string
+		Document string
+		Location string
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message:  "unknown $schema",
+			Document: `{"$schema": "http://example.com/schema"}`,
+			Location: "#/$schema",
+			Error:    schema.UnknownDraftError,
+		},
+		{
+			Message:  "numeric exclusive in draft-04",
+			Document: `{"$schema": "http://json-schema.org/draft-04/schema#", "exclusiveMaximum": 10}`,
+			Location: "#/exclusiveMaximum",
+			Error:    schema.DefinitionTypeError,
+		},
+		{
+			Message:  "boolean exclusive without limit in draft-04",
+			Document: `{"$schema": "http://json-schema.org/draft-04/schema#", "exclusiveMinimum": true}`,
+			Location: "#/exclusiveMinimum",
+			Error:    schema.DefinitionExclusiveNoLimitError,
+		},
+		{
+			Message:  "boolean exclusive in draft-07",
+			Document: `{"$schema": "http://json-schema.org/draft-07/schema#", "maximum": 1, "exclusiveMaximum": true}`,
+			Location: "#/exclusiveMaximum",
+			Error:    schema.DefinitionTypeError,
+		},
+		{
+			Message:  "items array in 2020-12",
+			Document: `{"items": [{}]}`,
+			Location: "#/items",
+			Error:    schema.DefinitionTypeError,
+		},
+		{
+			Message:  "boolean schema in draft-04",
+			Document: `{"$schema": "http://json-schema.org/draft-04/schema#", "properties": {"a": true}}`,
+			Location: "#/properties/a",
+			Error:    schema.DefinitionTypeError,
+		},
+		{
+			Message:  "negative maxLength",
+			Document: `{"properties": {"a": {"maxLength": -1}}}`,
+			Location: "#/properties/a/maxLength",
+			Error:    strings.MaxLengthDefinitionNoLengthError,
+		},
+		{
+			Message:  "unknown type",
+			Document: `{"type": "float"}`,
+			Location: "#/type",
+			Error:    schema.DefinitionUnknownTypeError,
+		},
+		{
+			Message:  "zero multipleOf",
+			Document: `{"multipleOf": 0}`,
+			Location: "#/multipleOf",
+			Error:    schema.DefinitionNotPositiveError,
+		},
+		{
+			Message:  "duplicated enum",
+			Document: `{"enum": [1, 1.0]}`,
+			Location: "#/enum",
+			Error:    schema.DefinitionDuplicationError,
+		},
+		{
+			Message:  "unresolvable $ref",
+			Document: `{"$ref": "#/definitions/missing"}`,
+			Location: "#/$ref",
+			Error:    schema.UnresolvableReferenceError,
+		},
+		{
+			Message:  "$ref to itself",
+			Document: `{"$ref": "#"}`,
+			Location: "#/$ref",
+			Error:    schema.ReferenceCycleError,
+		},
+		{
+			Message:  "$ref to a definition which references itself",
+			Document: `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+			Location: "#/$defs/a/$ref",
+			Error:    schema.ReferenceCycleError,
+		},
+		{
+			Message:  "$ref to the parent of allOf",
+			Document: `{"allOf": [{"$ref": "#"}]}`,
+			Location: "#/allOf/0/$ref",
+			Error:    schema.ReferenceCycleError,
+		},
+		{
+			Message:  "errorMessage which is not a string",
+			Document: `{"errorMessage": {"pattern": 1}}`,
+			Location: "#/errorMessage",
+			Error:    schema.DefinitionTypeError,
+		},
+	}
+	for _, c := range cases {
+		_, err := schema.Load([]byte(c.Document))
+		e, ok := err.(*schema.DefinitionError)
+		if !ok {
+			t.Errorf("Test with %s: expected DefinitionError, but actual %v", c.Message, err)
+			continue
+		}
+		if e.Location != c.Location || e.Err != c.Error {
+			t.Errorf("Test with %s: expected %s %v, but actual %s %v", c.Message, c.Location, c.Error, e.Location, e.Err)
+		}
+	}
+}
+
+func TestLoadWithInvalidPattern(t *testing.T) {
+	_, err := schema.Load([]byte(`{"pattern": "[a-z"}`))
+	e, ok := err.(*schema.DefinitionError)
+	if !ok {
+		t.Fatalf("expected DefinitionError, but actual %v", err)
+	}
+	if _, ok := e.Err.(strings.InvalidPatternError); !ok {
+		t.Errorf("expected InvalidPatternError, but actual %v", e.Err)
+	}
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+)
+
+// Limits bounds the evaluation of ValidateContext, so that the untrusted
+// schemas and instances don't run long. The zero MaxNodes is unlimited.
+type Limits struct {
+	// MaxDepth is the maximum depth of the schemas which are evaluated in
+	// each other, such as the properties, the items and $ref. The zero
+	// MaxDepth is DefaultMaxDepth, and a negative one is unlimited.
+	MaxDepth int `json:"max_depth"`
+	// MaxNodes is the maximum number of the evaluations of the schemas
+	// against the values.
+	MaxNodes int `json:"max_nodes"`
+}
+
+// LimitExceededError reports the validation which exceeds Limits.
+type LimitExceededError struct {
+	// Limit is "depth" or "nodes".
+	Limit        string `json:"limit"`
+	Max          int    `json:"max"`
+	InstancePath string `json:"instance_path"`
+}
+
+func (err LimitExceededError) Error() string {
+	path := err.InstancePath
+	if path == "" {
+		path = "(root)"
+	}
+	return fmt.Sprintf("the validation exceeds the %s limit %d at %s", err.Limit, err.Max, path)
+}
+
+// DefaultMaxDepth is MaxDepth of the zero Limits, which stops the deeply
+// nested instances of the recursive schemas before the stack overflows.
+const DefaultMaxDepth = 10000
+
+// checkInterval is the number of the evaluations between the checks of
+// the cancellation.
+const checkInterval = 64
+
+// evaluation is the state of ValidateContext.
+type evaluation struct {
+	ctx    context.Context
+	limits Limits
+	depth  int
+	nodes  int
+	// err stops the evaluation.
+	err error
+}
+
+// enter starts the evaluation of a schema against the value at path, and
+// reports whether it goes on. The nil evaluation is unlimited.
+func (ev *evaluation) enter(path string) bool {
+	if ev == nil {
+		return true
+	}
+	if ev.err != nil {
+		return false
+	}
+	ev.depth++
+	ev.nodes++
+	switch {
+	case ev.limits.MaxDepth > 0 && ev.depth > ev.limits.MaxDepth:
+		ev.err = &LimitExceededError{"depth", ev.limits.MaxDepth, path}
+	case ev.limits.MaxNodes > 0 && ev.nodes > ev.limits.MaxNodes:
+		ev.err = &LimitExceededError{"nodes", ev.limits.MaxNodes, path}
+	case ev.nodes%checkInterval == 1:
+		ev.err = ev.ctx.Err()
+	}
+	if ev.err != nil {
+		ev.depth--
+		return false
+	}
+	return true
+}
+
+func (ev *evaluation) leave() {
+	if ev != nil {
+		ev.depth--
+	}
+}
+
+func (ev *evaluation) context() context.Context {
+	if ev == nil {
+		return context.Background()
+	}
+	return ev.ctx
+}
+
+// ValidateContext validates instance like Validate, and stops when ctx is
+// done or the validation exceeds Limits of the Compiler which compiled s.
+// It returns the error of ctx such as context.DeadlineExceeded, or
+// LimitExceededError, instead of the errors found before it stops.
+//
+// The cancellation is checked at every 64 evaluations of the schemas, and
+// the custom keywords receive ctx in KeywordContext.
+func (s *Schema) ValidateContext(ctx context.Context, instance interface{}) error {
+	ev := &evaluation{ctx: ctx, limits: s.limits}
+	if ev.limits.MaxDepth == 0 {
+		ev.limits.MaxDepth = DefaultMaxDepth
+	}
+	errs := s.validate(ev, instance, "")
+	if ev.err != nil {
+		return ev.err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,146 @@
+package schema_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const treeDocument = `{
+	"$ref": "#/$defs/node",
+	"$defs": {
+		"node": {
+			"type": "object",
+			"properties": {"child": {"$ref": "#/$defs/node"}, "value": {"type": "integer"}}
+		}
+	}
+}`
+
+// tree returns the object nested in the child properties by depth.
+func tree(depth int) map[string]interface{} {
+	node := map[string]interface{}{"value": 1.0}
+	for i := 0; i < depth; i++ {
+		node = map[string]interface{}{"child": node}
+	}
+	return node
+}
+
+func TestValidateContext(t *testing.T) {
+	c := schema.NewCompiler()
+	c.Limits = schema.Limits{MaxDepth: 20, MaxNodes: 50}
+	s, err := c.Load([]byte(treeDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	items, err := c.Load([]byte(`{"items": {"type": "integer"}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	invalid := tree(3)
+	invalid["value"] = "a"
+
+	type Case struct {
+		Message  string
+		Context  context.Context
+		Schema   *schema.Schema
+		Instance interface{}
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message:  "valid",
+			Context:  context.Background(),
+			Schema:   s,
+			Instance: tree(5),
+		},
+		{
+			Message:  "invalid",
+			Context:  context.Background(),
+			Schema:   s,
+			Instance: invalid,
+			Error:    s.Validate(invalid),
+		},
+		{
+			Message:  "canceled",
+			Context:  canceled,
+			Schema:   s,
+			Instance: tree(5),
+			Error:    context.Canceled,
+		},
+		{
+			Message:  "deadline",
+			Context:  expired,
+			Schema:   s,
+			Instance: tree(5),
+			Error:    context.DeadlineExceeded,
+		},
+		{
+			Message:  "depth",
+			Context:  context.Background(),
+			Schema:   s,
+			Instance: tree(50),
+			Error:    &schema.LimitExceededError{Limit: "depth", Max: 20, InstancePath: strings.Repeat("/child", 10)},
+		},
+		{
+			Message:  "nodes",
+			Context:  context.Background(),
+			Schema:   items,
+			Instance: make([]interface{}, 100),
+			Error:    &schema.LimitExceededError{Limit: "nodes", Max: 50, InstancePath: "/49"},
+		},
+	}
+	for _, c := range cases {
+		err := c.Schema.ValidateContext(c.Context, c.Instance)
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+
+	if err := s.Validate(tree(50)); err != nil {
+		t.Errorf("expected Validate not to be limited, but actual %v", err)
+	}
+}
+
+func TestValidateContextWithDefaultLimits(t *testing.T) {
+	s, err := schema.Load([]byte(treeDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	if err := s.ValidateContext(context.Background(), tree(100)); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	err = s.ValidateContext(context.Background(), tree(schema.DefaultMaxDepth))
+	if e, ok := err.(*schema.LimitExceededError); !ok || e.Limit != "depth" || e.Max != schema.DefaultMaxDepth {
+		t.Errorf("expected the depth LimitExceededError, but actual %v", err)
+	}
+}
+
+func TestValidateContextWithCustomKeyword(t *testing.T) {
+	type key struct{}
+	var actual interface{}
+	c := schema.NewCompiler()
+	c.RegisterKeyword("x-context", func(value interface{}) (schema.KeywordEvaluator, error) {
+		return func(ctx *schema.KeywordContext, v interface{}) error {
+			actual = ctx.Context.Value(key{})
+			return nil
+		}, nil
+	})
+	s, err := c.Load([]byte(`{"x-context": true}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	if err := s.ValidateContext(context.WithValue(context.Background(), key{}, "value"), 1); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if actual != "value" {
+		t.Errorf("expected the context of ValidateContext, but actual %v", actual)
+	}
+}
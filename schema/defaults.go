@@ -0,0 +1,279 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AppliedDefault reports a missing property which ValidateWithDefaults has
+// filled in with the default of its schema.
+type AppliedDefault struct {
+	InstancePath string      `json:"instance_path"`
+	SchemaPath   string      `json:"schema_path"`
+	Value        interface{} `json:"value"`
+}
+
+// InvalidDefaultsTargetError for ValidateWithDefaults
+type InvalidDefaultsTargetError struct {
+	Input interface{} `json:"input"`
+}
+
+func (e InvalidDefaultsTargetError) Error() string {
+	return fmt.Sprintf("the instance of ValidateWithDefaults should be map[string]interface{} or pointer struct but %T", e.Input)
+}
+
+// ValidateWithDefaults fills in the missing properties of instance with the
+// copies of the defaults of their schemas, and validates it like Validate.
+//
+// The instance is a map[string]interface{} decoded by encoding/json, or a
+// pointer to a struct whose fields are named by json tags, and in which the
+// nil pointers, slices, maps and interfaces are missing. The other fields,
+// such as a string, are always present, so that an explicit 0, "" or false
+// is kept; use a pointer for the field which takes the default. The defaults are also applied to
+// the objects in the properties, the items and allOf; the branches of anyOf
+// and oneOf are not, unless a discriminator selects the branch.
+// The applied defaults are returned in the order they are applied.
+func (s *Schema) ValidateWithDefaults(instance interface{}) ([]*AppliedDefault, error) {
+	var applied []*AppliedDefault
+	if m, ok := instance.(map[string]interface{}); ok {
+		s.applyDefaults(m, "", &applied)
+		return applied, s.Validate(m)
+	}
+	rv := reflect.ValueOf(instance)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, &InvalidDefaultsTargetError{instance}
+	}
+	if err := s.applyStructDefaults(rv.Elem(), "", &applied); err != nil {
+		return applied, err
+	}
+	data, err := json.Marshal(instance)
+	if err != nil {
+		return applied, err
+	}
+	v, err := decodeJSON(data)
+	if err != nil {
+		return applied, err
+	}
+	return applied, s.Validate(v)
+}
+
+// defaultOf returns s, or the schema which s references, which has the default.
+func (s *Schema) defaultOf() (*Schema, bool) {
+	for ; s != nil; s = s.ref {
+		if s.HasDefault {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// subschemas returns the schemas of s whose defaults are applied to the same value.
+func (s *Schema) subschemas(v interface{}) []*Schema {
+	ss := []*Schema{s}
+	if s.ref != nil {
+		ss = append(ss, s.ref.subschemas(v)...)
+		if s.Draft <= Draft07 {
+			return ss
+		}
+	}
+	for _, sub := range s.AllOf {
+		ss = append(ss, sub.subschemas(v)...)
+	}
+	if d := s.Discriminator; d != nil {
+		if m, ok := v.(map[string]interface{}); ok {
+			value, _ := m[d.PropertyName].(string)
+			if sub, ok := d.Mapping[value]; ok {
+				ss = append(ss, sub.subschemas(v)...)
+			}
+		}
+	}
+	return ss
+}
+
+func (s *Schema) applyDefaults(v interface{}, path string, applied *[]*AppliedDefault) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		ss := s.subschemas(t)
+		for _, sub := range ss {
+			for _, name := range sortedKeys(sub.Properties) {
+				if _, ok := t[name]; ok {
+					continue
+				}
+				if d, ok := sub.Properties[name].defaultOf(); ok {
+					t[name] = deepCopy(d.Default)
+					*applied = append(*applied, &AppliedDefault{
+						InstancePath: path + "/" + EscapePointer(name),
+						SchemaPath:   d.Location + "/default",
+						Value:        deepCopy(d.Default),
+					})
+				}
+			}
+		}
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, sub := range ss {
+				if p, ok := sub.Properties[key]; ok {
+					p.applyDefaults(t[key], path+"/"+EscapePointer(key), applied)
+				} else if sub.AdditionalProperties != nil {
+					sub.AdditionalProperties.applyDefaults(t[key], path+"/"+EscapePointer(key), applied)
+				}
+			}
+		}
+	case []interface{}:
+		for _, sub := range s.subschemas(t) {
+			for i, item := range t {
+				p := path + "/" + strconv.Itoa(i)
+				if i < len(sub.PrefixItems) {
+					sub.PrefixItems[i].applyDefaults(item, p, applied)
+				} else if sub.Items != nil {
+					sub.Items.applyDefaults(item, p, applied)
+				}
+			}
+		}
+	}
+}
+
+// applyStructDefaults applies the defaults to the fields of the struct v.
+func (s *Schema) applyStructDefaults(v reflect.Value, path string, applied *[]*AppliedDefault) error {
+	t := v.Type()
+	for _, sub := range s.subschemas(nil) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" && !f.Anonymous {
+				continue
+			}
+			fv := v.Field(i)
+			name := jsonFieldName(f)
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				if fv.Kind() == reflect.Ptr {
+					if fv.IsNil() {
+						continue
+					}
+					fv = fv.Elem()
+				}
+				if err := sub.applyStructDefaults(fv, path, applied); err != nil {
+					return err
+				}
+				continue
+			}
+			p, ok := sub.Properties[name]
+			if !ok {
+				continue
+			}
+			fieldPath := path + "/" + EscapePointer(name)
+			if d, ok := p.defaultOf(); ok && isNil(fv) {
+				data, err := json.Marshal(d.Default)
+				if err != nil {
+					return err
+				}
+				if err := json.Unmarshal(data, fv.Addr().Interface()); err != nil {
+					return err
+				}
+				*applied = append(*applied, &AppliedDefault{
+					InstancePath: fieldPath,
+					SchemaPath:   d.Location + "/default",
+					Value:        deepCopy(d.Default),
+				})
+			}
+			if err := p.applyValueDefaults(fv, fieldPath, applied); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isNil reports whether v is a nil pointer, slice, map or interface, which
+// is a missing field.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// applyValueDefaults applies the defaults to the structs in v.
+func (s *Schema) applyValueDefaults(v reflect.Value, path string, applied *[]*AppliedDefault) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return s.applyValueDefaults(v.Elem(), path, applied)
+	case reflect.Struct:
+		return s.applyStructDefaults(v, path, applied)
+	case reflect.Slice, reflect.Array:
+		for _, sub := range s.subschemas(nil) {
+			for i := 0; i < v.Len(); i++ {
+				p := path + "/" + strconv.Itoa(i)
+				var item *Schema
+				if i < len(sub.PrefixItems) {
+					item = sub.PrefixItems[i]
+				} else {
+					item = sub.Items
+				}
+				if item == nil {
+					continue
+				}
+				if err := item.applyValueDefaults(v.Index(i), p, applied); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Map:
+		if m, ok := v.Interface().(map[string]interface{}); ok {
+			s.applyDefaults(m, path, applied)
+		}
+	}
+	return nil
+}
+
+// jsonFieldName returns the name of the field in JSON, or "" for the
+// embedded struct whose fields are promoted.
+func jsonFieldName(f reflect.StructField) string {
+	tag := f.Tag.Get("json")
+	if i := strings.Index(tag, ","); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag != "" {
+		return tag
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if f.Anonymous && t.Kind() == reflect.Struct {
+		return ""
+	}
+	return f.Name
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, e := range t {
+			m[key] = deepCopy(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = deepCopy(e)
+		}
+		return a
+	}
+	return v
+}
@@ -0,0 +1,217 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const defaultsDocument = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"role": {"type": "string", "default": "member"},
+		"settings": {
+			"type": "object",
+			"properties": {
+				"theme": {"$ref": "#/$defs/theme"},
+				"tags": {"type": "array", "default": ["new"]}
+			}
+		},
+		"emails": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"properties": {"verified": {"type": "boolean", "default": false}}
+			}
+		}
+	},
+	"allOf": [{"properties": {"limit": {"type": "integer", "default": 10}}}],
+	"$defs": {"theme": {"enum": ["light", "dark"], "default": "light"}}
+}`
+
+func TestValidateWithDefaults(t *testing.T) {
+	s, err := schema.Load([]byte(defaultsDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance string
+		Expected string
+		Applied  []string
+		Valid    bool
+	}
+	cases := []Case{
+		{
+			Message:  "missing properties",
+			Instance: `{"name": "foo", "settings": {}, "emails": [{}, {"verified": true}]}`,
+			Expected: `{"name": "foo", "role": "member", "limit": 10, "settings": {"theme": "light", "tags": ["new"]}, "emails": [{"verified": false}, {"verified": true}]}`,
+			Applied:  []string{"/role", "/limit", "/emails/0/verified", "/settings/tags", "/settings/theme"},
+			Valid:    true,
+		},
+		{
+			Message:  "present properties",
+			Instance: `{"role": "admin", "limit": 1}`,
+			Expected: `{"role": "admin", "limit": 1}`,
+			Valid:    true,
+		},
+		{
+			Message:  "invalid instance",
+			Instance: `{"settings": {"theme": "blue"}}`,
+			Expected: `{"role": "member", "limit": 10, "settings": {"theme": "blue", "tags": ["new"]}}`,
+			Applied:  []string{"/role", "/limit", "/settings/tags"},
+			Valid:    false,
+		},
+	}
+	for _, c := range cases {
+		instance, expected := decodeObject(t, c.Instance), decodeObject(t, c.Expected)
+		applied, err := s.ValidateWithDefaults(instance)
+		if valid := err == nil; valid != c.Valid {
+			t.Errorf("Test with %s: expected %v, but actual %v (%v)", c.Message, c.Valid, valid, err)
+		}
+		var paths []string
+		for _, a := range applied {
+			paths = append(paths, a.InstancePath)
+		}
+		if !reflect.DeepEqual(paths, c.Applied) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Applied, paths)
+		}
+		if !reflect.DeepEqual(instance, expected) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, expected, instance)
+		}
+	}
+}
+
+func TestValidateWithDefaultsCopiesDefaults(t *testing.T) {
+	s, err := schema.Load([]byte(defaultsDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	first := map[string]interface{}{"settings": map[string]interface{}{}}
+	if _, err := s.ValidateWithDefaults(first); err != nil {
+		t.Fatal(err)
+	}
+	first["settings"].(map[string]interface{})["tags"].([]interface{})[0] = "changed"
+
+	second := map[string]interface{}{"settings": map[string]interface{}{}}
+	applied, err := s.ValidateWithDefaults(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := second["settings"].(map[string]interface{})["tags"]; !reflect.DeepEqual(tags, []interface{}{"new"}) {
+		t.Errorf("expected [new], but actual %v", tags)
+	}
+	expected := []*schema.AppliedDefault{
+		{InstancePath: "/role", SchemaPath: "#/properties/role/default", Value: "member"},
+		{InstancePath: "/limit", SchemaPath: "#/allOf/0/properties/limit/default", Value: json.Number("10")},
+		{InstancePath: "/settings/tags", SchemaPath: "#/properties/settings/properties/tags/default", Value: []interface{}{"new"}},
+		{InstancePath: "/settings/theme", SchemaPath: "#/$defs/theme/default", Value: "light"},
+	}
+	if !reflect.DeepEqual(applied, expected) {
+		t.Errorf("expected %v, but actual %v", expected, applied)
+	}
+}
+
+func decodeObject(t *testing.T, document string) map[string]interface{} {
+	d := json.NewDecoder(strings.NewReader(document))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+type defaultsSettings struct {
+	Theme *string  `json:"theme,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type defaultsEmail struct {
+	Verified *bool `json:"verified,omitempty"`
+}
+
+type defaultsUser struct {
+	Name     string            `json:"name"`
+	Role     *string           `json:"role,omitempty"`
+	Limit    *int              `json:"limit,omitempty"`
+	Settings *defaultsSettings `json:"settings,omitempty"`
+	Emails   []defaultsEmail   `json:"emails,omitempty"`
+}
+
+func TestValidateWithDefaultsOfStruct(t *testing.T) {
+	s, err := schema.Load([]byte(defaultsDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	verified := true
+	user := &defaultsUser{
+		Name:     "foo",
+		Settings: &defaultsSettings{Tags: []string{"old"}},
+		Emails:   []defaultsEmail{{}, {Verified: &verified}},
+	}
+	applied, err := s.ValidateWithDefaults(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unverified, member, limit, light := false, "member", 10, "light"
+	expected := &defaultsUser{
+		Name:     "foo",
+		Role:     &member,
+		Limit:    &limit,
+		Settings: &defaultsSettings{Theme: &light, Tags: []string{"old"}},
+		Emails:   []defaultsEmail{{Verified: &unverified}, {Verified: &verified}},
+	}
+	if !reflect.DeepEqual(user, expected) {
+		t.Errorf("expected %+v, but actual %+v", expected, user)
+	}
+	var paths []string
+	for _, a := range applied {
+		paths = append(paths, a.InstancePath)
+	}
+	if expected := []string{"/role", "/settings/theme", "/emails/0/verified", "/limit"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("expected %v, but actual %v", expected, paths)
+	}
+
+	blue := "blue"
+	user = &defaultsUser{Settings: &defaultsSettings{Theme: &blue}}
+	if _, err := s.ValidateWithDefaults(user); err == nil {
+		t.Errorf("expected the error of enum")
+	}
+	if _, err := s.ValidateWithDefaults(defaultsUser{}); err == nil {
+		t.Errorf("expected InvalidDefaultsTargetError")
+	}
+}
+
+func TestValidateWithDefaultsKeepsZeroFields(t *testing.T) {
+	s, err := schema.Load([]byte(`{"properties": {
+		"role": {"type": "string", "default": "member"},
+		"limit": {"type": "integer", "default": 10},
+		"active": {"type": "boolean", "default": true}
+	}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	type Account struct {
+		Role   string `json:"role"`
+		Limit  *int   `json:"limit"`
+		Active bool   `json:"active"`
+	}
+	zero := 0
+	account := &Account{Limit: &zero}
+	applied, err := s.ValidateWithDefaults(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("expected no applied default, but actual %v", applied)
+	}
+	if expected := (&Account{Limit: &zero}); !reflect.DeepEqual(account, expected) {
+		t.Errorf("expected %+v, but actual %+v", expected, account)
+	}
+}
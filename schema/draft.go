@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Draft identifies a version of the JSON Schema specification.
+// It decides how the keywords of a schema document are interpreted.
+type Draft int
+
+const (
+	Draft04 Draft = iota + 1
+	Draft06
+	Draft07
+	Draft201909
+	Draft202012
+)
+
+// DefaultDraft is used for documents which don't declare $schema.
+const DefaultDraft = Draft202012
+
+var UnknownDraftError = errors.New("the $schema doesn't identify a supported draft")
+
+var drafts = []struct {
+	draft Draft
+	name  string
+	uri   string
+}{
+	{Draft04, "draft-04", "http://json-schema.org/draft-04/schema#"},
+	{Draft06, "draft-06", "http://json-schema.org/draft-06/schema#"},
+	{Draft07, "draft-07", "http://json-schema.org/draft-07/schema#"},
+	{Draft201909, "2019-09", "https://json-schema.org/draft/2019-09/schema"},
+	{Draft202012, "2020-12", "https://json-schema.org/draft/2020-12/schema"},
+}
+
+// DraftFromURI returns the draft identified by the value of $schema.
+// The scheme and the empty fragment are not significant.
+func DraftFromURI(uri string) (Draft, error) {
+	u := normalizeDraftURI(uri)
+	for _, d := range drafts {
+		if normalizeDraftURI(d.uri) == u {
+			return d.draft, nil
+		}
+	}
+	return 0, UnknownDraftError
+}
+
+// ParseDraft returns the draft with the given name such as "draft-07" or "2020-12".
+func ParseDraft(name string) (Draft, error) {
+	for _, d := range drafts {
+		if d.name == name || strings.TrimPrefix(d.name, "draft-") == name {
+			return d.draft, nil
+		}
+	}
+	return 0, UnknownDraftError
+}
+
+// URI returns the meta-schema URI of d.
+func (d Draft) URI() string {
+	for _, e := range drafts {
+		if e.draft == d {
+			return e.uri
+		}
+	}
+	return ""
+}
+
+func (d Draft) String() string {
+	for _, e := range drafts {
+		if e.draft == d {
+			return e.name
+		}
+	}
+	return fmt.Sprintf("Draft(%d)", int(d))
+}
+
+func normalizeDraftURI(uri string) string {
+	uri = strings.TrimSuffix(uri, "#")
+	uri = strings.TrimPrefix(uri, "https://")
+	uri = strings.TrimPrefix(uri, "http://")
+	return uri
+}
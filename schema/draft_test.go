@@ -0,0 +1,88 @@
+package schema_test
+
+import (
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestDraftFromURI(t *testing.T) {
+	type Case struct {
+		Message  string
+		URI      string
+		Expected schema.Draft
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message:  "draft-04",
+			URI:      "http://json-schema.org/draft-04/schema#",
+			Expected: schema.Draft04,
+		},
+		{
+			Message:  "draft-06 without empty fragment",
+			URI:      "http://json-schema.org/draft-06/schema",
+			Expected: schema.Draft06,
+		},
+		{
+			Message:  "draft-07 with https",
+			URI:      "https://json-schema.org/draft-07/schema#",
+			Expected: schema.Draft07,
+		},
+		{
+			Message:  "2019-09",
+			URI:      "https://json-schema.org/draft/2019-09/schema",
+			Expected: schema.Draft201909,
+		},
+		{
+			Message:  "2020-12",
+			URI:      "https://json-schema.org/draft/2020-12/schema",
+			Expected: schema.Draft202012,
+		},
+		{
+			Message: "unknown",
+			URI:     "https://example.com/schema",
+			Error:   schema.UnknownDraftError,
+		},
+	}
+	for _, c := range cases {
+		d, err := schema.DraftFromURI(c.URI)
+		if err != c.Error {
+			t.Errorf("Test with %s: expected error %v, but actual %v", c.Message, c.Error, err)
+		}
+		if d != c.Expected {
+			t.Errorf("Test with %s: expected %s, but actual %s", c.Message, c.Expected, d)
+		}
+	}
+}
+
+func TestParseDraft(t *testing.T) {
+	type Case struct {
+		Name     string
+		Expected schema.Draft
+		Error    error
+	}
+	cases := []Case{
+		{Name: "draft-04", Expected: schema.Draft04},
+		{Name: "04", Expected: schema.Draft04},
+		{Name: "draft-07", Expected: schema.Draft07},
+		{Name: "2019-09", Expected: schema.Draft201909},
+		{Name: "2020-12", Expected: schema.Draft202012},
+		{Name: "draft-05", Error: schema.UnknownDraftError},
+	}
+	for _, c := range cases {
+		d, err := schema.ParseDraft(c.Name)
+		if err != c.Error || d != c.Expected {
+			t.Errorf("Test with %s: expected (%s, %v), but actual (%s, %v)", c.Name, c.Expected, c.Error, d, err)
+		}
+	}
+}
+
+func TestURIOfDraft(t *testing.T) {
+	for _, d := range []schema.Draft{schema.Draft04, schema.Draft06, schema.Draft07, schema.Draft201909, schema.Draft202012} {
+		actual, err := schema.DraftFromURI(d.URI())
+		if err != nil || actual != d {
+			t.Errorf("Test with %s: URI %s identifies %s", d, d.URI(), actual)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package schema
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	jsstrings "github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+// ErrorMessage is the errorMessage keyword, which replaces the messages of
+// the errors of the schema and its subschemas, while the other fields of the
+// errors are unchanged.
+//
+// The keyword is a message for all the errors, or an object of the messages
+// by the keywords of the schema. In the object, "required" is also an object
+// of the messages by the missing properties, "properties" is an object of the
+// messages of the errors in the values of the properties, and "_" is the
+// message of the other errors:
+//
+//	"errorMessage": {
+//		"pattern": "should be a code such as ABC-1",
+//		"required": {"name": "the name is required"},
+//		"properties": {"age": "should be an age"},
+//		"_": "invalid user"
+//	}
+//
+// The messages are text/template templates executed with ErrorMessageData.
+// The messages of the inner schemas take precedence.
+type ErrorMessage struct {
+	// Default is the message of the errors which have no other message.
+	Default string
+	// Keywords are the messages of the errors of the keywords of the schema.
+	Keywords map[string]string
+	// Required are the messages of the missing properties of required.
+	Required map[string]string
+	// Properties are the messages of the errors in the values of the properties.
+	Properties map[string]string
+
+	defaultTemplate *template.Template
+	keywords        map[string]*template.Template
+	required        map[string]*template.Template
+	properties      map[string]*template.Template
+}
+
+// ErrorMessageData is the data of the templates of errorMessage.
+type ErrorMessageData struct {
+	// Input is the value which is invalid.
+	Input interface{}
+	// Limit is the value of the keyword which the input doesn't satisfy, such
+	// as 10 of maxLength and the pattern of pattern. It is nil for the
+	// keywords without limit.
+	Limit interface{}
+	// Property is the name of the property of required, dependentRequired
+	// and additionalProperties.
+	Property     string
+	Keyword      string
+	InstancePath string
+	// Err is the error of the validator.
+	Err error
+}
+
+// errorMessage parses the errorMessage keyword.
+func (k *keywords) errorMessage() {
+	v, ok := k.get("errorMessage")
+	if !ok {
+		return
+	}
+	em := &ErrorMessage{}
+	switch t := v.(type) {
+	case string:
+		em.Default = t
+	case map[string]interface{}:
+		for key, e := range t {
+			switch key {
+			case "_":
+				em.Default, ok = e.(string)
+			case "properties":
+				em.Properties, ok = messageMap(e)
+			case "required":
+				if em.Required, ok = messageMap(e); !ok {
+					em.Required = nil
+					ok = em.addKeyword(key, e)
+				}
+			default:
+				ok = em.addKeyword(key, e)
+			}
+			if !ok {
+				k.fail("errorMessage", DefinitionTypeError)
+				return
+			}
+		}
+	default:
+		k.fail("errorMessage", DefinitionTypeError)
+		return
+	}
+	if err := em.parse(); err != nil {
+		k.fail("errorMessage", err)
+		return
+	}
+	k.s.ErrorMessage = em
+}
+
+// addKeyword adds the message v of the keyword, and reports whether v is a string.
+func (em *ErrorMessage) addKeyword(keyword string, v interface{}) bool {
+	message, ok := v.(string)
+	if !ok {
+		return false
+	}
+	if em.Keywords == nil {
+		em.Keywords = map[string]string{}
+	}
+	em.Keywords[keyword] = message
+	return true
+}
+
+// messageMap returns the messages of the object v by the names.
+func messageMap(v interface{}) (map[string]string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	texts := make(map[string]string, len(m))
+	for name, e := range m {
+		text, ok := e.(string)
+		if !ok {
+			return nil, false
+		}
+		texts[name] = text
+	}
+	return texts, true
+}
+
+// parse parses the messages into the templates.
+func (em *ErrorMessage) parse() error {
+	var err error
+	if em.Default != "" {
+		if em.defaultTemplate, err = messages.Parse("_", em.Default); err != nil {
+			return err
+		}
+	}
+	if em.keywords, err = parseMessages(em.Keywords); err != nil {
+		return err
+	}
+	if em.required, err = parseMessages(em.Required); err != nil {
+		return err
+	}
+	em.properties, err = parseMessages(em.Properties)
+	return err
+}
+
+func parseMessages(m map[string]string) (map[string]*template.Template, error) {
+	if m == nil {
+		return nil, nil
+	}
+	templates := make(map[string]*template.Template, len(m))
+	for key, text := range m {
+		t, err := messages.Parse(key, text)
+		if err != nil {
+			return nil, err
+		}
+		templates[key] = t
+	}
+	return templates, nil
+}
+
+// apply sets the messages of the errors of s for the value v at path,
+// unless they already have messages.
+func (em *ErrorMessage) apply(s *Schema, errs ValidationErrors, v interface{}, path string) {
+	for _, e := range errs {
+		if e.message != "" {
+			continue
+		}
+		if t := em.template(s, e, path); t != nil {
+			data := newErrorMessageData(e, v, path)
+			var b bytes.Buffer
+			if err := t.Execute(&b, data); err == nil {
+				e.message = b.String()
+			}
+		}
+	}
+}
+
+// template returns the template of the error e of s for the value at path.
+func (em *ErrorMessage) template(s *Schema, e *ValidationError, path string) *template.Template {
+	if e.InstancePath == path && e.SchemaPath == s.Location+"/"+EscapePointer(e.Keyword) {
+		if r, ok := e.Err.(*RequiredValidationError); ok {
+			if t, ok := em.required[r.Property]; ok {
+				return t
+			}
+		}
+		if t, ok := em.keywords[e.Keyword]; ok {
+			return t
+		}
+	}
+	if strings.HasPrefix(e.InstancePath, path+"/") {
+		name := e.InstancePath[len(path)+1:]
+		if i := strings.IndexByte(name, '/'); i >= 0 {
+			name = name[:i]
+		}
+		if t, ok := em.properties[unescapePointer(name)]; ok {
+			return t
+		}
+	}
+	return em.defaultTemplate
+}
+
+func newErrorMessageData(e *ValidationError, v interface{}, path string) *ErrorMessageData {
+	data := &ErrorMessageData{
+		Keyword:      e.Keyword,
+		InstancePath: e.InstancePath,
+		Err:          e.Err,
+	}
+	if strings.HasPrefix(e.InstancePath, path) {
+		data.Input, _ = valueAt(v, e.InstancePath[len(path):])
+	}
+	switch err := e.Err.(type) {
+	case *TypeValidationError:
+		data.Limit = err.Types
+	case *EnumValidationError:
+		data.Limit = err.Enum
+	case *ConstValidationError:
+		data.Limit = err.Const
+	case *MultipleOfValidationError:
+		data.Limit = err.MultipleOf
+	case *ContainsValidationError:
+		data.Limit = err.MinContains
+		if err.MaxContains != nil && err.Matched > *err.MaxContains {
+			data.Limit = *err.MaxContains
+		}
+	case *RequiredValidationError:
+		data.Limit, data.Property = err.Required, err.Property
+	case *DependentRequiredValidationError:
+		data.Limit, data.Property = err.Required, err.Property
+	case *AdditionalPropertiesValidationError:
+		data.Property = err.Property
+	case *MaxPropertiesValidationError:
+		data.Limit = err.MaxProperties
+	case *MinPropertiesValidationError:
+		data.Limit = err.MinProperties
+	case *jsstrings.MaxLengthValidationError:
+		data.Limit = err.Definition.MaxLength
+	case *jsstrings.MinLengthValidationError:
+		data.Limit = err.Definition.MinLength
+	case *jsstrings.PatternValidationError:
+		data.Limit = err.Definition.Pattern
+	case *jsstrings.FormatValidationError:
+		data.Limit = err.Definition.Format
+	case *numbers.MaximumValidationError:
+		data.Limit = err.Definition.Maximum
+	case *numbers.MinimumValidationError:
+		data.Limit = err.Definition.Minimum
+	case *bignumbers.MaximumValidationError:
+		data.Limit = err.Definition.Maximum
+	case *bignumbers.MinimumValidationError:
+		data.Limit = err.Definition.Minimum
+	case *bignumbers.MultipleOfValidationError:
+		data.Limit = err.Definition.MultipleOf
+	case *arrays.MaxItemsValidationError:
+		data.Limit = err.Definition.MaxItems
+	case *arrays.MinItemsValidationError:
+		data.Limit = err.Definition.MinItems
+	}
+	return data
+}
+
+// valueAt returns the value at the JSON Pointer in v.
+func valueAt(v interface{}, pointer string) (interface{}, bool) {
+	for _, token := range splitPointer(pointer) {
+		var ok bool
+		if v, ok = child(v, token); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,98 @@
+package schema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+func TestValidateWithErrorMessage(t *testing.T) {
+	s, err := schema.Load([]byte(`{
+		"type": "object",
+		"required": ["code", "name", "age"],
+		"properties": {
+			"code": {
+				"type": "string",
+				"pattern": "^[A-Z]{3}-\\d+$",
+				"maxLength": 8,
+				"errorMessage": {"pattern": "'{{.Input}}' should be a code such as ABC-1"}
+			},
+			"name": {"type": "string", "maxLength": 5},
+			"age": {"type": "integer", "minimum": 0},
+			"tags": {"type": "array", "items": {"type": "string", "errorMessage": "the tag {{.InstancePath}} should be a string"}}
+		},
+		"errorMessage": {
+			"required": {"name": "the name is required"},
+			"properties": {"code": "the code is invalid", "age": "the age should be {{.Limit}} or more but {{.Input}}"},
+			"_": "invalid {{.Keyword}}"
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance string
+		Errors   []string
+	}
+	cases := []Case{
+		{
+			Message:  "keyword of the property",
+			Instance: `{"code": "abc", "name": "Alice", "age": 20}`,
+			Errors:   []string{"/code: 'abc' should be a code such as ABC-1"},
+		},
+		{
+			Message:  "property",
+			Instance: `{"code": "ABC-123456", "name": "Alice", "age": -1}`,
+			Errors:   []string{"/age: the age should be 0 or more but -1", "/code: the code is invalid"},
+		},
+		{
+			Message:  "required",
+			Instance: `{"code": "ABC-1"}`,
+			Errors:   []string{"(root): the name is required", "(root): invalid required"},
+		},
+		{
+			Message:  "default and inner schema",
+			Instance: `{"code": "ABC-1", "name": "Alexander", "age": 1, "tags": ["a", 1]}`,
+			Errors:   []string{"/name: invalid maxLength", "/tags/1: the tag /tags/1 should be a string"},
+		},
+	}
+	for _, c := range cases {
+		v, err := decodeInstance([]byte(c.Instance))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var errors []string
+		if errs, ok := s.Validate(v).(schema.ValidationErrors); ok {
+			for _, e := range errs {
+				errors = append(errors, e.Error())
+			}
+		}
+		if !reflect.DeepEqual(errors, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, errors)
+		}
+	}
+}
+
+func TestValidateWithErrorMessageKeepsDetails(t *testing.T) {
+	s, err := schema.Load([]byte(`{"maxLength": 2, "errorMessage": "too long"}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	errs := s.Validate("foo").(schema.ValidationErrors)
+	e := errs[0]
+	expected := &strings.MaxLengthValidationError{Definition: strings.MaxLengthValidatorDefinition{MaxLength: 2}, Input: "foo"}
+	if e.Keyword != "maxLength" || e.SchemaPath != "#/maxLength" || !reflect.DeepEqual(e.Err, expected) {
+		t.Errorf("expected the details of maxLength, but actual %v", e)
+	}
+	if actual := e.Message(messages.Japanese); actual != "too long" {
+		t.Errorf("expected the message of errorMessage in any catalog, but actual %q", actual)
+	}
+	if actual := errs.Localize(messages.Japanese).Error(); actual != "(root): too long" {
+		t.Errorf("expected the message of errorMessage, but actual %q", actual)
+	}
+}
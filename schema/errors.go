@@ -0,0 +1,279 @@
+package schema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+)
+
+var (
+	DefinitionTypeError               = errors.New("the value of the keyword has an invalid type")
+	DefinitionNonNegativeIntegerError = errors.New("the value of the keyword should be a non-negative integer")
+	DefinitionNotPositiveError        = errors.New("the value of the keyword should be greater than 0")
+	DefinitionUnknownTypeError        = errors.New("the type should be one of null, boolean, object, array, number, string or integer")
+	DefinitionExclusiveNoLimitError   = errors.New("the boolean exclusive keyword should be used with the limit")
+	DefinitionEmptyError              = errors.New("the value of the keyword should have at least one element")
+	DefinitionDuplicationError        = errors.New("the elements of the keyword shouldn't be duplicated")
+	UnresolvableReferenceError        = errors.New("the reference can't be resolved")
+	ReferenceCycleError               = errors.New("the reference leads back to the schema without evaluating a property or an item")
+
+	DefinitionDiscriminatorPropertyNameError = errors.New("the discriminator should have the propertyName")
+)
+
+// DefinitionError reports an invalid keyword in a schema document.
+type DefinitionError struct {
+	Location string `json:"location"`
+	Keyword  string `json:"keyword"`
+	Err      error  `json:"error"`
+}
+
+func (e DefinitionError) Error() string {
+	return fmt.Sprintf("invalid schema at '%s': %s", e.Location, e.Err)
+}
+
+func (e DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionErrors is a list of DefinitionError returned by Lint.
+type DefinitionErrors []*DefinitionError
+
+func (errs DefinitionErrors) Error() string {
+	var b bytes.Buffer
+	for i, err := range errs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// ValidationError reports a keyword which the instance doesn't satisfy.
+// The Err is the error of the validator which evaluated the keyword.
+type ValidationError struct {
+	InstancePath string `json:"instance_path"`
+	SchemaPath   string `json:"schema_path"`
+	Keyword      string `json:"keyword"`
+	Err          error  `json:"error"`
+	// Offset is the byte offset of the value in the input, which is set by
+	// StreamValidator.
+	Offset int64 `json:"offset,omitempty"`
+	// Line and Column are the position of the value in the YAML document,
+	// which are set by ValidateYAML and Locate of YAMLDocument.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// message is the message of errorMessage of the schema.
+	message string
+	catalog *messages.Catalog
+}
+
+func (e ValidationError) Error() string {
+	path := e.InstancePath
+	if path == "" {
+		path = "(root)"
+	}
+	if e.message != "" || e.catalog != nil {
+		return fmt.Sprintf("%s: %s", path, e.Message(e.catalog))
+	}
+	return fmt.Sprintf("%s: %s", path, e.Err)
+}
+
+// Message returns the message of Err in the catalog c, or the message of
+// errorMessage of the schema, which takes precedence.
+func (e ValidationError) Message(c *messages.Catalog) string {
+	if e.message != "" {
+		return e.message
+	}
+	return c.Message(e.Keyword, e.Err)
+}
+
+// ValidationErrors is a list of ValidationError returned by Validate of Schema.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	var b bytes.Buffer
+	for i, err := range errs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Localize returns the copies of errs whose Error renders the messages in
+// the catalog c, such as messages.Lookup("ja"). The other fields are the same.
+func (errs ValidationErrors) Localize(c *messages.Catalog) ValidationErrors {
+	localized := make(ValidationErrors, len(errs))
+	for i, err := range errs {
+		e := *err
+		e.catalog = c
+		localized[i] = &e
+	}
+	return localized
+}
+
+// Prefix prepends path to the InstancePath of the errors, and returns errs.
+// It is for the errors of a value which is validated apart from its parent.
+func (errs ValidationErrors) Prefix(path string) ValidationErrors {
+	for _, err := range errs {
+		err.InstancePath = path + err.InstancePath
+	}
+	return errs
+}
+
+// FalseSchemaValidationError for the boolean schema false
+type FalseSchemaValidationError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err FalseSchemaValidationError) Error() string {
+	return "any value is not allowed"
+}
+
+type TypeValidationError struct {
+	Types []string    `json:"types"`
+	Input interface{} `json:"input"`
+}
+
+func (err TypeValidationError) Error() string {
+	return fmt.Sprintf("input value %v should be %v", err.Input, err.Types)
+}
+
+type EnumValidationError struct {
+	Enum  []interface{} `json:"enum"`
+	Input interface{}   `json:"input"`
+}
+
+func (err EnumValidationError) Error() string {
+	return fmt.Sprintf("input value %v doesn't exist in %v", err.Input, err.Enum)
+}
+
+type ConstValidationError struct {
+	Const interface{} `json:"const"`
+	Input interface{} `json:"input"`
+}
+
+func (err ConstValidationError) Error() string {
+	return fmt.Sprintf("input value %v should be %v", err.Input, err.Const)
+}
+
+type MultipleOfValidationError struct {
+	MultipleOf float64 `json:"multiple_of"`
+	Input      float64 `json:"input"`
+}
+
+func (err MultipleOfValidationError) Error() string {
+	return fmt.Sprintf("the value %v should be a multiple of %v", err.Input, err.MultipleOf)
+}
+
+type UniqueItemsValidationError struct {
+	Input []interface{} `json:"input"`
+}
+
+func (err UniqueItemsValidationError) Error() string {
+	return fmt.Sprintf("the items of %v should be unique", err.Input)
+}
+
+type ContainsValidationError struct {
+	MinContains int  `json:"min_contains"`
+	MaxContains *int `json:"max_contains"`
+	Matched     int  `json:"matched"`
+}
+
+func (err ContainsValidationError) Error() string {
+	if err.MaxContains != nil && err.Matched > *err.MaxContains {
+		return fmt.Sprintf("should contain at most %d matching items but actual %d", *err.MaxContains, err.Matched)
+	}
+	return fmt.Sprintf("should contain at least %d matching items but actual %d", err.MinContains, err.Matched)
+}
+
+type RequiredValidationError struct {
+	Required []string `json:"required"`
+	Property string   `json:"property"`
+}
+
+func (err RequiredValidationError) Error() string {
+	return fmt.Sprintf("the property '%s' is required", err.Property)
+}
+
+type DependentRequiredValidationError struct {
+	Dependent string   `json:"dependent"`
+	Required  []string `json:"required"`
+	Property  string   `json:"property"`
+}
+
+func (err DependentRequiredValidationError) Error() string {
+	return fmt.Sprintf("the property '%s' is required when '%s' is present", err.Property, err.Dependent)
+}
+
+type AdditionalPropertiesValidationError struct {
+	Property string `json:"property"`
+}
+
+func (err AdditionalPropertiesValidationError) Error() string {
+	return fmt.Sprintf("the property '%s' is not allowed", err.Property)
+}
+
+type MaxPropertiesValidationError struct {
+	MaxProperties int `json:"max_properties"`
+	Properties    int `json:"properties"`
+}
+
+func (err MaxPropertiesValidationError) Error() string {
+	return fmt.Sprintf("should have less than, or equal to, %d properties but actual value has %d properties",
+		err.MaxProperties, err.Properties)
+}
+
+type MinPropertiesValidationError struct {
+	MinProperties int `json:"min_properties"`
+	Properties    int `json:"properties"`
+}
+
+func (err MinPropertiesValidationError) Error() string {
+	return fmt.Sprintf("should have greater than, or equal to, %d properties but actual value has %d properties",
+		err.MinProperties, err.Properties)
+}
+
+type AnyOfValidationError struct {
+	Errors []ValidationErrors `json:"errors"`
+}
+
+func (err AnyOfValidationError) Error() string {
+	return "input value doesn't match any of the schemas"
+}
+
+type OneOfValidationError struct {
+	Matched int                `json:"matched"`
+	Errors  []ValidationErrors `json:"errors"`
+}
+
+func (err OneOfValidationError) Error() string {
+	if err.Matched == 0 {
+		return "input value doesn't match any of the schemas"
+	}
+	return fmt.Sprintf("input value should match exactly one schema but matches %d schemas", err.Matched)
+}
+
+type DiscriminatorValidationError struct {
+	PropertyName string      `json:"property_name"`
+	Values       []string    `json:"values"`
+	Input        interface{} `json:"input"`
+}
+
+func (err DiscriminatorValidationError) Error() string {
+	return fmt.Sprintf("the property '%s' should be one of %v to select the schema, but actual %v",
+		err.PropertyName, err.Values, err.Input)
+}
+
+type NotValidationError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err NotValidationError) Error() string {
+	return fmt.Sprintf("input value %v should not match the schema", err.Input)
+}
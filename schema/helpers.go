@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+)
+
+// typeOf returns the JSON type name of v, or "" if v is not a JSON value.
+// Numbers are reported as "number" even if they are integers.
+func typeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if _, ok := toFloat(v); ok {
+		return "number"
+	}
+	return ""
+}
+
+// hasType returns whether v is an instance of the JSON type t.
+func hasType(v interface{}, t string) bool {
+	if t == "integer" {
+		return isInteger(v)
+	}
+	return typeOf(v) == t
+}
+
+// toFloat returns the value of the number v.
+// The ok reports whether v is a number.
+func toFloat(v interface{}) (f float64, ok bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		if f, err := strconv.ParseFloat(string(n), 64); err == nil {
+			return f, true
+		}
+	}
+	if r, ok := bignumbers.Rat(v); ok {
+		f, _ := r.Float64()
+		return f, true
+	}
+	return 0, false
+}
+
+// exactRat returns the exact value of the number v which is not a float,
+// such as json.Number, int64 and big.Int, which are compared exactly.
+func exactRat(v interface{}) (*big.Rat, bool) {
+	switch v.(type) {
+	case float32, float64:
+		return nil, false
+	}
+	return bignumbers.Rat(v)
+}
+
+// toInt returns the value of the number v.
+// The ok reports whether v is an integer which fits in int.
+func toInt(v interface{}) (i int, ok bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := strconv.ParseInt(string(n), 10, 0); err == nil {
+			return int(i), true
+		}
+	}
+	f, ok := toFloat(v)
+	if !ok || f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func isInteger(v interface{}) bool {
+	if r, ok := exactRat(v); ok {
+		return r.IsInt()
+	}
+	f, ok := toFloat(v)
+	if !ok || math.IsInf(f, 0) {
+		return false
+	}
+	return f == math.Trunc(f)
+}
+
+// equal returns whether a and b are the same JSON value.
+// Numbers are compared by their values.
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	if typeOf(a) == "number" {
+		if x, ok := exactRat(a); ok {
+			if y, ok := exactRat(b); ok {
+				return x.Cmp(y) == 0
+			}
+		}
+		f, _ := toFloat(a)
+		g, ok := toFloat(b)
+		return ok && f == g
+	}
+	return typeOf(a) != "" && typeOf(a) == typeOf(b) && a == b
+}
+
+// EscapePointer escapes a reference token of JSON Pointer.
+func EscapePointer(token string) string {
+	token = strings.Replace(token, "~", "~0", -1)
+	return strings.Replace(token, "/", "~1", -1)
+}
+
+// unescapePointer unescapes a reference token of JSON Pointer.
+func unescapePointer(token string) string {
+	token = strings.Replace(token, "~1", "/", -1)
+	return strings.Replace(token, "~0", "~", -1)
+}
+
+// splitPointer splits JSON Pointer into the unescaped reference tokens.
+func splitPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = unescapePointer(t)
+	}
+	return tokens
+}
+
+// resolveURI resolves ref against base.
+func resolveURI(base, ref string) (string, error) {
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	if base == "" {
+		return r.String(), nil
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
+
+// splitFragment splits uri into the URI without fragment and the unescaped fragment.
+func splitFragment(uri string) (string, string) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		i := strings.Index(uri, "#")
+		if i < 0 {
+			return uri, ""
+		}
+		return uri[:i], uri[i+1:]
+	}
+	fragment := u.Fragment
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), fragment
+}
+
+// refName returns the name at the end of the reference, such as "Dog" of
+// "#/components/schemas/Dog".
+func refName(ref string) string {
+	return unescapePointer(ref[strings.LastIndex(ref, "/")+1:])
+}
+
+// isReference reports whether ref of a discriminator mapping is a reference
+// rather than a schema name.
+func isReference(ref string) bool {
+	return strings.ContainsAny(ref, "/#")
+}
@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"context"
+	"sort"
+)
+
+// KeywordCompiler compiles the value of a custom keyword in a schema into
+// its evaluator. The error is reported as DefinitionError of the keyword,
+// like the invalid values of the built-in keywords.
+type KeywordCompiler func(value interface{}) (KeywordEvaluator, error)
+
+// KeywordEvaluator evaluates a custom keyword against the value v, and
+// returns the error which is reported as the Err of ValidationError.
+type KeywordEvaluator func(ctx *KeywordContext, v interface{}) error
+
+// KeywordContext is the context of the evaluation of a custom keyword.
+type KeywordContext struct {
+	// Context is the context of ValidateContext, or context.Background.
+	Context context.Context
+	// Keyword is the name of the keyword.
+	Keyword string
+	// InstancePath is the JSON Pointer of the value in the instance.
+	InstancePath string
+	// Schema is the schema which has the keyword.
+	Schema *Schema
+}
+
+// Annotation returns the value of the annotation keyword of the schema,
+// such as title, description, default, and the keywords in Extra.
+func (ctx *KeywordContext) Annotation(keyword string) (interface{}, bool) {
+	s := ctx.Schema
+	switch keyword {
+	case "title":
+		return s.Title, s.Title != ""
+	case "description":
+		return s.Description, s.Description != ""
+	case "default":
+		return s.Default, s.HasDefault
+	}
+	v, ok := s.Extra[keyword]
+	return v, ok
+}
+
+type customKeyword struct {
+	name     string
+	evaluate KeywordEvaluator
+}
+
+// RegisterKeyword registers the custom keyword name, such as "x-luhn", to c.
+// The schemas compiled by c evaluate it with the evaluator which compile
+// returns for its value. The keywords of the drafts take precedence, and
+// the custom keywords are evaluated in the order of their names after them.
+func (c *Compiler) RegisterKeyword(name string, compile KeywordCompiler) {
+	if c.keywords == nil {
+		c.keywords = map[string]KeywordCompiler{}
+	}
+	c.keywords[name] = compile
+}
+
+// customKeywords compiles the custom keywords of the schema.
+func (k *keywords) customKeywords() {
+	var names []string
+	for name := range k.p.keywords {
+		if _, ok := k.m[name]; ok && !k.seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v, _ := k.get(name)
+		evaluate, err := k.p.keywords[name](v)
+		if err != nil {
+			k.fail(name, err)
+			continue
+		}
+		k.s.custom = append(k.s.custom, customKeyword{name, evaluate})
+	}
+}
+
+// validateCustom evaluates the custom keywords of s.
+func (s *Schema) validateCustom(ev *evaluation, v interface{}, path string, fail func(string, error)) {
+	for _, c := range s.custom {
+		ctx := &KeywordContext{Context: ev.context(), Keyword: c.name, InstancePath: path, Schema: s}
+		if err := c.evaluate(ctx, v); err != nil {
+			fail(c.name, err)
+		}
+	}
+}
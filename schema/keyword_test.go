@@ -0,0 +1,183 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+var (
+	luhnDefinitionError = errors.New("x-luhn should be true")
+	luhnError           = errors.New("the check digit is wrong")
+	sortedByError       = errors.New("the items are not sorted")
+)
+
+// compileLuhn compiles x-luhn, which validates the check digit of the numeric strings.
+func compileLuhn(value interface{}) (schema.KeywordEvaluator, error) {
+	if b, ok := value.(bool); !ok || !b {
+		return nil, luhnDefinitionError
+	}
+	return func(ctx *schema.KeywordContext, v interface{}) error {
+		s, ok := v.(string)
+		if !ok {
+			return nil
+		}
+		sum := 0
+		for i := range s {
+			d := int(s[len(s)-1-i] - '0')
+			if d < 0 || d > 9 {
+				return luhnError
+			}
+			if i%2 == 1 {
+				if d *= 2; d > 9 {
+					d -= 9
+				}
+			}
+			sum += d
+		}
+		if sum%10 != 0 {
+			return luhnError
+		}
+		return nil
+	}, nil
+}
+
+// compileSortedBy compiles x-sorted-by, which validates that the objects of an
+// array are sorted by the string property.
+func compileSortedBy(value interface{}) (schema.KeywordEvaluator, error) {
+	name, ok := value.(string)
+	if !ok {
+		return nil, schema.DefinitionTypeError
+	}
+	return func(ctx *schema.KeywordContext, v interface{}) error {
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		prev := ""
+		for _, item := range items {
+			m, _ := item.(map[string]interface{})
+			key, _ := m[name].(string)
+			if key < prev {
+				return sortedByError
+			}
+			prev = key
+		}
+		return nil
+	}, nil
+}
+
+func newKeywordCompiler() *schema.Compiler {
+	c := schema.NewCompiler()
+	c.RegisterKeyword("x-luhn", compileLuhn)
+	c.RegisterKeyword("x-sorted-by", compileSortedBy)
+	return c
+}
+
+func TestValidateWithCustomKeywords(t *testing.T) {
+	s, err := newKeywordCompiler().Load([]byte(`{
+		"properties": {
+			"card": {"type": "string", "x-luhn": true},
+			"items": {"type": "array", "x-sorted-by": "name"}
+		},
+		"x-unknown": 1
+	}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	if !reflect.DeepEqual(s.Extra, map[string]interface{}{"x-unknown": json.Number("1")}) {
+		t.Errorf("expected the custom keywords not to be in Extra, but actual %v", s.Extra)
+	}
+
+	type Case struct {
+		Message  string
+		Instance string
+		Errors   []string
+	}
+	cases := []Case{
+		{
+			Message:  "valid",
+			Instance: `{"card": "79927398713", "items": [{"name": "a"}, {"name": "b"}]}`,
+		},
+		{
+			Message:  "invalid",
+			Instance: `{"card": "79927398710", "items": [{"name": "b"}, {"name": "a"}]}`,
+			Errors: []string{
+				"/card #/properties/card/x-luhn x-luhn the check digit is wrong",
+				"/items #/properties/items/x-sorted-by x-sorted-by the items are not sorted",
+			},
+		},
+		{
+			Message:  "other types",
+			Instance: `{"card": 1, "items": {}}`,
+			Errors: []string{
+				"/card #/properties/card/type type input value 1 should be [string]",
+				"/items #/properties/items/type type input value map[] should be [array]",
+			},
+		},
+	}
+	for _, c := range cases {
+		v, err := decodeInstance([]byte(c.Instance))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var actual []string
+		errs, _ := s.Validate(v).(schema.ValidationErrors)
+		for _, e := range errs {
+			actual = append(actual, fmt.Sprintf("%s %s %s %s", e.InstancePath, e.SchemaPath, e.Keyword, e.Err))
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+
+		d := json.NewDecoder(strings.NewReader(c.Instance))
+		d.UseNumber()
+		if actual, expected := keywords((&schema.StreamValidator{Schema: s}).Validate(d)), keywords(errs); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Test with %s: expected %v of StreamValidator, but actual %v", c.Message, expected, actual)
+		}
+	}
+}
+
+func TestLoadWithInvalidCustomKeywords(t *testing.T) {
+	_, err := newKeywordCompiler().Load([]byte(`{"properties": {"card": {"x-luhn": false}}}`))
+	e, ok := err.(*schema.DefinitionError)
+	if !ok {
+		t.Fatalf("expected DefinitionError, but actual %v", err)
+	}
+	if e.Location != "#/properties/card/x-luhn" || e.Keyword != "x-luhn" || e.Err != luhnDefinitionError {
+		t.Errorf("expected the error of x-luhn, but actual %v", e)
+	}
+
+	if _, err := schema.Load([]byte(`{"x-luhn": false}`)); err != nil {
+		t.Errorf("expected the keyword not to be registered to the default Compiler, but actual %v", err)
+	}
+}
+
+func TestKeywordContext(t *testing.T) {
+	var actual []string
+	c := schema.NewCompiler()
+	c.RegisterKeyword("x-log", func(value interface{}) (schema.KeywordEvaluator, error) {
+		return func(ctx *schema.KeywordContext, v interface{}) error {
+			title, _ := ctx.Annotation("title")
+			unit, _ := ctx.Annotation("x-unit")
+			_, ok := ctx.Annotation("description")
+			actual = append(actual, fmt.Sprintf("%s %s %v %v %v %v", ctx.Keyword, ctx.InstancePath, title, unit, ok, v))
+			return nil
+		}, nil
+	})
+	s, err := c.Load([]byte(`{"items": {"title": "Length", "x-unit": "cm", "x-log": true}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	if err := s.Validate([]interface{}{1.5}); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if expected := []string{"x-log /0 Length cm false 1.5"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
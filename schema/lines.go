@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// LineResult is the result of a record of JSON Lines.
+type LineResult struct {
+	// Line is the line number of the record, which starts at 1.
+	Line int
+	// Err is ValidationErrors of the invalid record, or the error of
+	// decoding the line. It is nil when the record is valid.
+	Err error
+}
+
+// LinesValidator validates the records of JSON Lines (NDJSON) concurrently.
+// The compiled Schema is shared by the workers, since it is not modified by
+// the validation.
+type LinesValidator struct {
+	Schema *Schema
+	// Workers is the number of the goroutines which decode and validate the
+	// records. It is runtime.GOMAXPROCS(0) when not positive.
+	Workers int
+}
+
+type lineJob struct {
+	line   int
+	data   []byte
+	result chan LineResult
+}
+
+// Validate validates every line of r as a JSON value, and calls emit with
+// the results in the order of the lines. The blank lines are skipped.
+// It stops at the first error of emit, which is returned, and returns the
+// error of reading r otherwise.
+func (v *LinesValidator) Validate(r io.Reader, emit func(LineResult) error) error {
+	workers := v.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	jobs := make(chan lineJob)
+	// pending holds the results to emit in order, which bounds the
+	// records read ahead.
+	pending := make(chan chan LineResult, workers*2)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				j.result <- v.validateLine(j.line, j.data)
+			}
+		}()
+	}
+
+	var readErr error
+	read := make(chan struct{})
+	go func() {
+		defer close(read)
+		defer close(pending)
+		defer close(jobs)
+		br := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			data, err := br.ReadBytes('\n')
+			if len(bytes.TrimSpace(data)) > 0 {
+				j := lineJob{line, data, make(chan LineResult, 1)}
+				select {
+				case pending <- j.result:
+				case <-done:
+					return
+				}
+				select {
+				case jobs <- j:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	var err error
+	for result := range pending {
+		if err = emit(<-result); err != nil {
+			break
+		}
+	}
+	close(done)
+	<-read
+	wg.Wait()
+	if err != nil {
+		return err
+	}
+	return readErr
+}
+
+func (v *LinesValidator) validateLine(line int, data []byte) LineResult {
+	instance, err := decodeJSON(data)
+	if err != nil {
+		return LineResult{line, err}
+	}
+	return LineResult{line, v.Schema.Validate(instance)}
+}
@@ -0,0 +1,90 @@
+package schema_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestLinesValidator(t *testing.T) {
+	s, err := schema.Load([]byte(`{"type": "object", "required": ["id"], "properties": {"id": {"type": "integer"}}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	var lines []string
+	var expected []string
+	for i := 1; i <= 1000; i++ {
+		switch {
+		case i%100 == 0:
+			lines = append(lines, "")
+		case i == 555:
+			lines = append(lines, `{"id": 1`)
+			expected = append(expected, "555 unexpected EOF")
+		case i%7 == 0:
+			lines = append(lines, fmt.Sprintf(`{"id": "%d"}`, i))
+			expected = append(expected, fmt.Sprintf("%d /id type", i))
+		default:
+			lines = append(lines, fmt.Sprintf(`{"id": %d}`, i))
+			expected = append(expected, fmt.Sprintf("%d valid", i))
+		}
+	}
+	for _, workers := range []int{0, 1, 8} {
+		v := &schema.LinesValidator{Schema: s, Workers: workers}
+		var actual []string
+		err := v.Validate(strings.NewReader(strings.Join(lines, "\n")), func(r schema.LineResult) error {
+			switch err := r.Err.(type) {
+			case nil:
+				actual = append(actual, fmt.Sprintf("%d valid", r.Line))
+			case schema.ValidationErrors:
+				actual = append(actual, fmt.Sprintf("%d %s %s", r.Line, err[0].InstancePath, err[0].Keyword))
+			default:
+				actual = append(actual, fmt.Sprintf("%d %s", r.Line, err))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Test with %d workers: unexpected error %v", workers, err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Test with %d workers: expected %d results, but actual %d results", workers, len(expected), len(actual))
+		}
+	}
+}
+
+func TestLinesValidatorWithErrors(t *testing.T) {
+	s, err := schema.Load([]byte(`{"type": "integer"}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	v := &schema.LinesValidator{Schema: s, Workers: 2}
+
+	stop := errors.New("stop")
+	var lines []int
+	err = v.Validate(strings.NewReader(strings.Repeat("1\n", 100)), func(r schema.LineResult) error {
+		lines = append(lines, r.Line)
+		if r.Line == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || !reflect.DeepEqual(lines, []int{1, 2, 3}) {
+		t.Errorf("expected to stop at the line 3, but actual %v, %v", lines, err)
+	}
+
+	broken := errors.New("broken")
+	lines = nil
+	err = v.Validate(io.MultiReader(strings.NewReader("1\n2\n"), iotest.ErrReader(broken)), func(r schema.LineResult) error {
+		lines = append(lines, r.Line)
+		return nil
+	})
+	if err != broken || !reflect.DeepEqual(lines, []int{1, 2}) {
+		t.Errorf("expected the error of reading after the line 2, but actual %v, %v", lines, err)
+	}
+}
@@ -0,0 +1,215 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Warning reports a keyword which is valid but is probably a mistake.
+type Warning struct {
+	Location string `json:"location"`
+	Keyword  string `json:"keyword"`
+	Message  string `json:"message"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("suspicious schema at '%s': %s", w.Location, w.Message)
+}
+
+// annotations are the keywords which are kept in Extra without warnings.
+var annotations = map[string]bool{
+	"$defs": true, "definitions": true, "examples": true, "readOnly": true,
+	"writeOnly": true, "deprecated": true, "contentMediaType": true,
+	"contentEncoding": true, "contentSchema": true, "$recursiveAnchor": true,
+	"$schema": true, "$comment": true, "title": true, "description": true,
+	"default": true,
+}
+
+// keywordNames are the keywords of all drafts, which are ignored in
+// some drafts or aren't supported.
+var keywordNames = map[string]bool{
+	"$ref": true, "$recursiveRef": true, "$dynamicRef": true, "type": true,
+	"enum": true, "const": true, "multipleOf": true, "maximum": true,
+	"exclusiveMaximum": true, "minimum": true, "exclusiveMinimum": true,
+	"maxLength": true, "minLength": true, "pattern": true, "format": true,
+	"prefixItems": true, "items": true, "additionalItems": true,
+	"contains": true, "maxContains": true, "minContains": true,
+	"maxItems": true, "minItems": true, "uniqueItems": true,
+	"unevaluatedItems": true, "properties": true, "patternProperties": true,
+	"additionalProperties": true, "propertyNames": true, "required": true,
+	"dependencies": true, "dependentRequired": true, "dependentSchemas": true,
+	"unevaluatedProperties": true, "maxProperties": true, "minProperties": true,
+	"allOf": true, "anyOf": true, "oneOf": true, "not": true, "if": true,
+	"then": true, "else": true, "title": true, "description": true,
+	"default": true, "$id": true, "id": true,
+}
+
+// Lint compiles doc with the default Compiler, and reports all problems.
+func Lint(doc interface{}) (DefinitionErrors, []*Warning) {
+	return NewCompiler().Lint(doc)
+}
+
+// Lint compiles doc, and returns all DefinitionErrors and the Warnings for
+// the suspicious schemas. The document is validated against the meta-schema
+// of its draft, which is reported as MetaSchemaError where the compiler
+// doesn't find an error, and the keywords are checked by the constructors of
+// the validators; the warnings
+// are for the empty ranges of the limits such as minLength greater than
+// maxLength, the branches of oneOf which can never be the only match, the
+// required properties which are not allowed, and the unknown keywords.
+// Both are sorted by their locations.
+func (c *Compiler) Lint(doc interface{}) (DefinitionErrors, []*Warning) {
+	s, p := c.compile(doc)
+	errs := append(DefinitionErrors{}, p.errs...)
+	errs = validateMetaSchema(doc, s.Draft, errs)
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Location < errs[j].Location })
+
+	locations := make([]string, 0, len(p.schemas))
+	for location := range p.schemas {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+	var warnings []*Warning
+	for _, location := range locations {
+		l := &linter{s: p.schemas[location], raws: p.raws}
+		l.lint()
+		warnings = append(warnings, l.warnings...)
+	}
+	sort.SliceStable(warnings, func(i, j int) bool { return warnings[i].Location < warnings[j].Location })
+	if len(errs) == 0 {
+		errs = nil
+	}
+	return errs, warnings
+}
+
+type linter struct {
+	s        *Schema
+	raws     map[string]interface{}
+	warnings []*Warning
+}
+
+// warn adds the warning for the keyword of the schema at location.
+func (l *linter) warn(location, keyword, format string, args ...interface{}) {
+	l.warnAt(location+"/"+EscapePointer(keyword), keyword, format, args...)
+}
+
+// warnAt adds the warning at location, which is a subschema of the keyword.
+func (l *linter) warnAt(location, keyword, format string, args ...interface{}) {
+	l.warnings = append(l.warnings, &Warning{
+		Location: location,
+		Keyword:  keyword,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) lint() {
+	s := l.s
+	if s.Boolean != nil {
+		return
+	}
+	l.limits("minLength", s.MinLength, "maxLength", s.MaxLength, "string")
+	l.limits("minItems", s.MinItems, "maxItems", s.MaxItems, "array")
+	l.limits("minContains", s.MinContains, "maxContains", s.MaxContains, "array")
+	l.limits("minProperties", s.MinProperties, "maxProperties", s.MaxProperties, "object")
+	l.numbers()
+	l.oneOf()
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Boolean != nil && !*s.AdditionalProperties.Boolean {
+		for _, name := range s.Required {
+			if _, ok := s.Properties[name]; ok {
+				continue
+			}
+			matched := false
+			for _, re := range s.patternProperties {
+				matched = matched || re.MatchString(name)
+			}
+			if !matched {
+				l.warn(s.Location, "required", "the required property '%s' is not allowed by additionalProperties, so no object is valid", name)
+			}
+		}
+	}
+	for _, key := range sortedExtraKeys(s.Extra) {
+		switch {
+		case annotations[key] || strings.HasPrefix(key, "x-"):
+		case s.Ref != "" && s.Draft <= Draft07:
+			l.warn(s.Location, key, "%s is ignored next to $ref in %s", key, s.Draft)
+		case keywordNames[key]:
+			l.warn(s.Location, key, "%s is not a keyword of %s, so it is ignored", key, s.Draft)
+		default:
+			l.warn(s.Location, key, "unknown keyword %s is ignored", key)
+		}
+	}
+}
+
+// limits warns when the lower limit is greater than the upper limit.
+func (l *linter) limits(minKeyword string, min *int, maxKeyword string, max *int, t string) {
+	if min != nil && max != nil && *min > *max {
+		l.warn(l.s.Location, minKeyword, "%s %d is greater than %s %d, so no %s is valid", minKeyword, *min, maxKeyword, *max, t)
+	}
+}
+
+// numbers warns when no number is in the range of the limits.
+func (l *linter) numbers() {
+	s := l.s
+	var lo, hi *float64
+	var loKeyword, hiKeyword string
+	loExclusive, hiExclusive := false, false
+	if s.Minimum != nil {
+		lo, loKeyword = s.Minimum, "minimum"
+	}
+	if s.ExclusiveMinimum != nil && (lo == nil || *s.ExclusiveMinimum >= *lo) {
+		lo, loKeyword, loExclusive = s.ExclusiveMinimum, "exclusiveMinimum", true
+	}
+	if s.Maximum != nil {
+		hi, hiKeyword = s.Maximum, "maximum"
+	}
+	if s.ExclusiveMaximum != nil && (hi == nil || *s.ExclusiveMaximum <= *hi) {
+		hi, hiKeyword, hiExclusive = s.ExclusiveMaximum, "exclusiveMaximum", true
+	}
+	if lo == nil || hi == nil {
+		return
+	}
+	if *lo > *hi || (*lo == *hi && (loExclusive || hiExclusive)) {
+		l.warn(s.Location, loKeyword, "%s %v and %s %v leave no valid number", loKeyword, *lo, hiKeyword, *hi)
+	}
+}
+
+// oneOf warns for the branches which can never be the only match.
+func (l *linter) oneOf() {
+	s := l.s
+	for i, sub := range s.OneOf {
+		switch {
+		case sub.Boolean != nil && !*sub.Boolean:
+			l.warnAt(sub.Location, "oneOf", "the branch never matches")
+		case len(s.Types) > 0 && len(sub.Types) > 0 && !overlapTypes(s.Types, sub.Types):
+			l.warnAt(sub.Location, "oneOf", "the branch never matches because its type %v is not in %v", sub.Types, s.Types)
+		default:
+			for j := 0; j < i; j++ {
+				if equal(l.raws[s.OneOf[j].Location], l.raws[sub.Location]) {
+					l.warnAt(sub.Location, "oneOf", "the branch is the same as %s, so neither matches alone", s.OneOf[j].Location)
+					break
+				}
+			}
+		}
+	}
+}
+
+func overlapTypes(a, b []string) bool {
+	for _, t := range a {
+		for _, u := range b {
+			if t == u || (t == "number" && u == "integer") || (t == "integer" && u == "number") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sortedExtraKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,157 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+func TestLintReportsAllErrors(t *testing.T) {
+	doc := decode(t, `{
+		"properties": {
+			"a": {"maxLength": -1},
+			"b": {"enum": ["x", "x"]},
+			"c": {"pattern": "[a-z"},
+			"d": {"type": "float"}
+		},
+		"items": {"$ref": "#/$defs/missing"}
+	}`)
+	errs, _ := schema.Lint(doc)
+
+	expected := []string{
+		"#/items/$ref",
+		"#/properties/a/maxLength",
+		"#/properties/b/enum",
+		"#/properties/c/pattern",
+		"#/properties/d/type",
+	}
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, e.Location)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, but actual %v", expected, actual)
+	}
+	if errs[1].Err != strings.MaxLengthDefinitionNoLengthError {
+		t.Errorf("expected %v, but actual %v", strings.MaxLengthDefinitionNoLengthError, errs[1].Err)
+	}
+	if _, ok := errs[3].Err.(strings.InvalidPatternError); !ok {
+		t.Errorf("expected InvalidPatternError, but actual %v", errs[3].Err)
+	}
+}
+
+func TestLintWithMetaSchema(t *testing.T) {
+	errs, _ := schema.Lint(decode(t, `{
+		"$comment": 1,
+		"properties": {
+			"$id": {"readOnly": "yes"},
+			"a": {"maxLength": -1}
+		}
+	}`))
+	expected := []string{
+		"#/$comment",
+		"#/properties/$id/readOnly",
+		"#/properties/a/maxLength",
+	}
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, e.Location)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, but actual %v", expected, actual)
+	}
+	if _, ok := errs[1].Err.(*schema.MetaSchemaError); !ok || errs[1].Keyword != "readOnly" {
+		t.Errorf("expected MetaSchemaError, but actual %v", errs[1])
+	}
+	if errs[2].Err != strings.MaxLengthDefinitionNoLengthError {
+		t.Errorf("expected %v, but actual %v", strings.MaxLengthDefinitionNoLengthError, errs[2].Err)
+	}
+}
+
+func TestLintWithReferenceCycle(t *testing.T) {
+	errs, _ := schema.Lint(decode(t, `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`))
+	expected := schema.DefinitionErrors{{Location: "#/$defs/a/$ref", Keyword: "$ref", Err: schema.ReferenceCycleError}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("expected %v, but actual %v", expected, errs)
+	}
+}
+
+func TestLintWarnings(t *testing.T) {
+	type Case struct {
+		Message  string
+		Document string
+		Warnings []string
+	}
+	cases := []Case{
+		{
+			Message:  "no warnings",
+			Document: `{"type": "string", "minLength": 1, "maxLength": 1, "examples": ["a"], "x-internal": true}`,
+			Warnings: nil,
+		},
+		{
+			Message:  "minLength greater than maxLength",
+			Document: `{"properties": {"a": {"minLength": 5, "maxLength": 3}}}`,
+			Warnings: []string{"#/properties/a/minLength"},
+		},
+		{
+			Message:  "minItems and minProperties greater than the max",
+			Document: `{"minItems": 2, "maxItems": 1, "minProperties": 2, "maxProperties": 1}`,
+			Warnings: []string{"#/minItems", "#/minProperties"},
+		},
+		{
+			Message:  "minimum greater than maximum",
+			Document: `{"minimum": 10, "maximum": 1}`,
+			Warnings: []string{"#/minimum"},
+		},
+		{
+			Message:  "exclusive limits at the same value",
+			Document: `{"minimum": 1, "exclusiveMaximum": 1}`,
+			Warnings: []string{"#/minimum"},
+		},
+		{
+			Message:  "inclusive limits at the same value",
+			Document: `{"minimum": 1, "maximum": 1}`,
+			Warnings: nil,
+		},
+		{
+			Message:  "unreachable oneOf branches",
+			Document: `{"type": "string", "oneOf": [{"maxLength": 1}, false, {"type": "integer"}, {"maxLength": 1}, {"minLength": 3}]}`,
+			Warnings: []string{"#/oneOf/1", "#/oneOf/2", "#/oneOf/3"},
+		},
+		{
+			Message:  "required property which is not allowed",
+			Document: `{"properties": {"a": {}}, "patternProperties": {"^x-": {}}, "additionalProperties": false, "required": ["a", "b", "x-c"]}`,
+			Warnings: []string{"#/required"},
+		},
+		{
+			Message:  "unknown and ignored keywords",
+			Document: `{"$schema": "http://json-schema.org/draft-07/schema#", "maxlength": 1, "prefixItems": [], "properties": {"a": {"$ref": "#", "maxLength": 1}}}`,
+			Warnings: []string{"#/maxlength", "#/prefixItems", "#/properties/a/maxLength"},
+		},
+	}
+	for _, c := range cases {
+		errs, warnings := schema.Lint(decode(t, c.Document))
+		if errs != nil {
+			t.Errorf("Test with %s: unexpected errors %v", c.Message, errs)
+			continue
+		}
+		var actual []string
+		for _, w := range warnings {
+			actual = append(actual, w.Location)
+		}
+		if !reflect.DeepEqual(actual, c.Warnings) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Warnings, actual)
+		}
+	}
+}
+
+func decode(t *testing.T, document string) interface{} {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(document), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
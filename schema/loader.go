@@ -0,0 +1,230 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UnsupportedURIError is returned by the loaders for the URIs which they
+// don't load, so that Loaders tries the next one.
+var UnsupportedURIError = errors.New("the URI is not supported by the loader")
+
+// Loader loads the schema documents of the URIs which $ref references out
+// of the compiled document, such as "https://schemas.example.com/user.json"
+// or "file:///repo/schemas/user.json".
+type Loader interface {
+	Load(uri string) ([]byte, error)
+}
+
+// LoaderFunc is a function which loads a document as Loader.
+type LoaderFunc func(uri string) ([]byte, error)
+
+func (f LoaderFunc) Load(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// Loaders loads a document with the first loader which supports the URI.
+type Loaders []Loader
+
+func (ls Loaders) Load(uri string) ([]byte, error) {
+	for _, l := range ls {
+		data, err := l.Load(uri)
+		if !errors.Is(err, UnsupportedURIError) {
+			return data, err
+		}
+	}
+	return nil, UnsupportedURIError
+}
+
+// MemoryLoader loads the documents in memory by their URIs.
+type MemoryLoader map[string][]byte
+
+func (l MemoryLoader) Load(uri string) ([]byte, error) {
+	data, ok := l[uri]
+	if !ok {
+		return nil, UnsupportedURIError
+	}
+	return data, nil
+}
+
+// FileLoader loads the documents of the file URIs from the local filesystem.
+type FileLoader struct{}
+
+func (FileLoader) Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, UnsupportedURIError
+	}
+	return os.ReadFile(filepath.FromSlash(u.Path))
+}
+
+// FSLoader loads the documents of the URIs under Base from FS, such as
+// embed.FS. The path of a document in FS is its URI without Base, so
+// FSLoader{FS: os.DirFS("schemas"), Base: "https://schemas.example.com/"}
+// loads "https://schemas.example.com/user.json" from "schemas/user.json".
+type FSLoader struct {
+	FS   fs.FS
+	Base string
+}
+
+func (l FSLoader) Load(uri string) ([]byte, error) {
+	if !strings.HasPrefix(uri, l.Base) {
+		return nil, UnsupportedURIError
+	}
+	name, err := url.PathUnescape(strings.TrimPrefix(uri, l.Base))
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(l.FS, name)
+}
+
+// DirLoader maps the URI prefixes to the local directories, such as
+// "https://schemas.example.com/" to "./schemas", so that the canonical IDs
+// resolve offline. The longest prefix of a URI is used.
+type DirLoader map[string]string
+
+func (l DirLoader) Load(uri string) ([]byte, error) {
+	var prefix string
+	for p := range l {
+		if strings.HasPrefix(uri, p) && len(p) > len(prefix) {
+			prefix = p
+		}
+	}
+	if prefix == "" {
+		return nil, UnsupportedURIError
+	}
+	return FSLoader{FS: os.DirFS(l[prefix]), Base: prefix}.Load(uri)
+}
+
+// HTTPLoader loads the documents of the http and https URIs with Client,
+// or http.DefaultClient if it's nil.
+type HTTPLoader struct {
+	Client *http.Client
+}
+
+func (l HTTPLoader) Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, UnsupportedURIError
+	}
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// LoadError reports a document which Loader fails to load or decode.
+type LoadError struct {
+	URI string `json:"uri"`
+	Err error  `json:"error"`
+}
+
+func (e LoadError) Error() string {
+	return fmt.Sprintf("can't load '%s': %s", e.URI, e.Err)
+}
+
+func (e LoadError) Unwrap() error {
+	return e.Err
+}
+
+// LoadURI loads the document of uri with Loader and compiles it, so that
+// the relative references in it are resolved against uri. The documents
+// whose paths end with .yaml or .yml are YAML, and the others are JSON.
+func (c *Compiler) LoadURI(uri string) (*Schema, error) {
+	s, _, err := c.compileURI(c.Loader, uri)
+	return s, err
+}
+
+// LoadFile loads the document of the file path and compiles it like
+// LoadURI. The documents are loaded with FileLoader if Loader is nil.
+func (c *Compiler) LoadFile(path string) (*Schema, error) {
+	uri, err := fileURI(path)
+	if err != nil {
+		return nil, err
+	}
+	s, _, err := c.compileURI(c.fileLoader(), uri)
+	return s, err
+}
+
+func (c *Compiler) fileLoader() Loader {
+	if c.Loader == nil {
+		return FileLoader{}
+	}
+	return c.Loader
+}
+
+// fileURI returns the file URI of the path.
+func fileURI(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}
+	return u.String(), nil
+}
+
+// compileURI loads the document of uri with loader, and compiles it.
+func (c *Compiler) compileURI(loader Loader, uri string) (*Schema, *compilation, error) {
+	data, err := withMetaSchemas(loader).Load(uri)
+	if err != nil {
+		return nil, nil, &LoadError{uri, err}
+	}
+	doc, err := decodeDocument(uri, data)
+	if err != nil {
+		return nil, nil, &LoadError{uri, err}
+	}
+	p, root := c.begin(doc, uri)
+	p.loader = loader
+	s := p.compile(doc, root.location, root.base, root.draft, true)
+	p.resolvePending()
+	if len(p.errs) > 0 {
+		return nil, nil, p.errs[0]
+	}
+	return s, p, nil
+}
+
+// load loads the document of uri, and registers its resources.
+func (p *compilation) load(uri string, draft Draft) error {
+	if err, ok := p.failures[uri]; ok {
+		return err
+	}
+	data, err := withMetaSchemas(p.loader).Load(uri)
+	var doc interface{}
+	if err == nil {
+		doc, err = decodeDocument(uri, data)
+	}
+	if err != nil {
+		if p.failures == nil {
+			p.failures = map[string]error{}
+		}
+		p.failures[uri] = &LoadError{uri, err}
+		return p.failures[uri]
+	}
+	location := uri + "#"
+	p.resources[uri] = &resource{doc, location, uri, draft}
+	p.documents = append(p.documents, uri)
+	p.scan(doc, location, uri, draft)
+	return nil
+}
@@ -0,0 +1,182 @@
+package schema_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const userDocument = `{
+	"$id": "https://schemas.example.com/order.json",
+	"properties": {"user": {"$ref": "user.json"}}
+}`
+
+func readTestdata(t *testing.T, name string) []byte {
+	data, err := ioutil.ReadFile("testdata/loader/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestLoadWithLoader(t *testing.T) {
+	user, common := readTestdata(t, "user.json"), readTestdata(t, "common.json")
+	type Case struct {
+		Message string
+		Loader  schema.Loader
+	}
+	cases := []Case{
+		{
+			Message: "MemoryLoader",
+			Loader: schema.MemoryLoader{
+				"https://schemas.example.com/user.json":   user,
+				"https://schemas.example.com/common.json": common,
+			},
+		},
+		{
+			Message: "FSLoader",
+			Loader: schema.FSLoader{
+				FS:   fstest.MapFS{"user.json": {Data: user}, "common.json": {Data: common}},
+				Base: "https://schemas.example.com/",
+			},
+		},
+		{
+			Message: "DirLoader",
+			Loader:  schema.DirLoader{"https://schemas.example.com/": "testdata/loader", "https://example.com/": "testdata"},
+		},
+		{
+			Message: "Loaders",
+			Loader: schema.Loaders{
+				schema.MemoryLoader{"https://schemas.example.com/user.json": user},
+				schema.FileLoader{},
+				schema.LoaderFunc(func(uri string) ([]byte, error) { return common, nil }),
+			},
+		},
+	}
+	for _, c := range cases {
+		compiler := schema.NewCompiler()
+		compiler.Loader = c.Loader
+		s, err := compiler.Load([]byte(userDocument))
+		if err != nil {
+			t.Errorf("Test with %s: fail to Load: %s", c.Message, err)
+			continue
+		}
+		if err := s.Validate(map[string]interface{}{"user": map[string]interface{}{"name": "abc"}}); err != nil {
+			t.Errorf("Test with %s: expected no error, but actual %v", c.Message, err)
+		}
+		expected := []string{
+			"/user required https://schemas.example.com/user.json#/required",
+			"/user/name maxLength https://schemas.example.com/common.json#/$defs/name/maxLength",
+		}
+		for i, instance := range []interface{}{
+			map[string]interface{}{"user": map[string]interface{}{}},
+			map[string]interface{}{"user": map[string]interface{}{"name": "abcdef"}},
+		} {
+			if actual := keywords(s.Validate(instance)); !reflect.DeepEqual(actual, expected[i:i+1]) {
+				t.Errorf("Test with %s: expected %v, but actual %v", c.Message, expected[i:i+1], actual)
+			}
+		}
+	}
+}
+
+func TestLoadWithHTTPLoader(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/user.json":
+			w.Write([]byte(`{"properties": {"name": {"$ref": "/common.json#/$defs/name"}}}`))
+		case "/common.json":
+			w.Write(readTestdata(t, "common.json"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	compiler := schema.NewCompiler()
+	compiler.Loader = schema.HTTPLoader{Client: server.Client()}
+	s, err := compiler.LoadURI(server.URL + "/user.json")
+	if err != nil {
+		t.Fatalf("Fail to LoadURI: %s", err)
+	}
+	if err := s.Validate(map[string]interface{}{"name": "abcdef"}); err == nil {
+		t.Errorf("expected the error of maxLength")
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, but actual %d", requests)
+	}
+
+	_, err = compiler.Load([]byte(`{"properties": {"a": {"$ref": "` + server.URL + `/missing.json"}, "b": {"$ref": "` + server.URL + `/missing.json#/a"}}}`))
+	var lerr *schema.LoadError
+	if !errors.As(err, &lerr) || lerr.URI != server.URL+"/missing.json" {
+		t.Errorf("expected LoadError, but actual %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected the failure to be loaded once, but actual %d requests", requests)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	compiler := schema.NewCompiler()
+	compiler.Loader = schema.Loaders{
+		schema.DirLoader{"https://schemas.example.com/": "testdata/loader"},
+		schema.FileLoader{},
+	}
+	s, err := compiler.LoadFile("testdata/loader/order.json")
+	if err != nil {
+		t.Fatalf("Fail to LoadFile: %s", err)
+	}
+	instance := map[string]interface{}{
+		"user":  map[string]interface{}{"name": "abc"},
+		"items": []interface{}{map[string]interface{}{"name": "abcdef"}},
+	}
+	expected := []string{"/items/0/name maxLength"}
+	var actual []string
+	if errs, ok := s.Validate(instance).(schema.ValidationErrors); ok {
+		for _, e := range errs {
+			actual = append(actual, e.InstancePath+" "+e.Keyword)
+		}
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
+
+func TestLoadWithoutLoader(t *testing.T) {
+	_, err := schema.Load([]byte(userDocument))
+	expected := &schema.DefinitionError{Location: "#/properties/user/$ref", Keyword: "$ref", Err: schema.UnresolvableReferenceError}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+	if _, err := schema.NewCompiler().LoadURI("https://schemas.example.com/user.json"); !errors.Is(err, schema.UnsupportedURIError) {
+		t.Errorf("expected UnsupportedURIError, but actual %v", err)
+	}
+}
+
+func TestLoadWithMetaSchema(t *testing.T) {
+	s, err := schema.Load([]byte(`{"properties": {"schema": {"$ref": "http://json-schema.org/draft-07/schema#"}}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	if err := s.Validate(map[string]interface{}{"schema": map[string]interface{}{"minLength": 1.0}}); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if err := s.Validate(map[string]interface{}{"schema": map[string]interface{}{"minLength": -1.0}}); err == nil {
+		t.Errorf("expected the error of the invalid schema")
+	}
+	for _, uri := range []string{"https://json-schema.org/draft/2020-12/meta/core", "http://json-schema.org/draft-04/schema#"} {
+		if _, err := (schema.MetaSchemaLoader{}).Load(uri); err != nil {
+			t.Errorf("Fail to load %s: %s", uri, err)
+		}
+	}
+	if _, err := (schema.MetaSchemaLoader{}).Load("https://json-schema.org/draft/2020-12/unknown"); !errors.Is(err, schema.UnsupportedURIError) {
+		t.Errorf("expected UnsupportedURIError, but actual %v", err)
+	}
+}
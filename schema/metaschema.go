@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"sync"
+)
+
+//go:embed metaschemas
+var metaSchemaFS embed.FS
+
+// MetaSchemaLoader loads the meta-schemas of the drafts, and the
+// vocabulary meta-schemas of 2019-09 and 2020-12, which are embedded in the
+// package, such as "http://json-schema.org/draft-07/schema#". The scheme
+// and the fragment are not significant. The compilations resolve these
+// URIs with it before Loader of Compiler, so they are never downloaded.
+type MetaSchemaLoader struct{}
+
+func (MetaSchemaLoader) Load(uri string) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host != "json-schema.org" {
+		return nil, UnsupportedURIError
+	}
+	data, err := fs.ReadFile(metaSchemaFS, "metaschemas/json-schema.org"+u.Path+".json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, UnsupportedURIError
+	}
+	return data, err
+}
+
+// withMetaSchemas returns the loader which loads the meta-schemas before
+// the documents of loader.
+func withMetaSchemas(loader Loader) Loader {
+	if loader == nil {
+		return MetaSchemaLoader{}
+	}
+	return Loaders{MetaSchemaLoader{}, loader}
+}
+
+var metaSchemas struct {
+	sync.Mutex
+	schemas map[Draft]*Schema
+}
+
+// MetaSchema returns the compiled meta-schema of d, against which the
+// schema documents of d are valid.
+func MetaSchema(d Draft) (*Schema, error) {
+	metaSchemas.Lock()
+	defer metaSchemas.Unlock()
+	if s, ok := metaSchemas.schemas[d]; ok {
+		return s, nil
+	}
+	s, err := NewCompiler().LoadURI(d.URI())
+	if err != nil {
+		return nil, err
+	}
+	if metaSchemas.schemas == nil {
+		metaSchemas.schemas = map[Draft]*Schema{}
+	}
+	metaSchemas.schemas[d] = s
+	return s, nil
+}
+
+// MetaSchemaError reports a value in a schema document which the
+// meta-schema of its draft doesn't allow.
+type MetaSchemaError struct {
+	// SchemaPath is the location of the keyword in the meta-schema.
+	SchemaPath string `json:"schema_path"`
+	Err        error  `json:"error"`
+}
+
+func (e MetaSchemaError) Error() string {
+	return fmt.Sprintf("the meta-schema doesn't allow the value: %s", e.Err)
+}
+
+func (e MetaSchemaError) Unwrap() error {
+	return e.Err
+}
+
+// validateMetaSchema validates doc against the meta-schema of draft, and
+// adds the errors to errs except at the locations which errs already has.
+func validateMetaSchema(doc interface{}, draft Draft, errs DefinitionErrors) DefinitionErrors {
+	m, err := MetaSchema(draft)
+	if err != nil {
+		return append(errs, &DefinitionError{Location: "#", Keyword: "$schema", Err: err})
+	}
+	reported := map[string]bool{}
+	for _, e := range errs {
+		reported[e.Location] = true
+	}
+	verrs, _ := m.Validate(doc).(ValidationErrors)
+	for _, e := range verrs {
+		location := "#" + e.InstancePath
+		if reported[location] {
+			continue
+		}
+		reported[location] = true
+		keyword := ""
+		if tokens := splitPointer(e.InstancePath); len(tokens) > 0 {
+			if t := tokens[len(tokens)-1]; keywordNames[t] || annotations[t] {
+				keyword = t
+			}
+		}
+		errs = append(errs, &DefinitionError{
+			Location: location,
+			Keyword:  keyword,
+			Err:      &MetaSchemaError{e.SchemaPath, e.Err},
+		})
+	}
+	return errs
+}
@@ -0,0 +1,156 @@
+package schema
+
+import (
+	"regexp"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+// Schema is a compiled JSON Schema.
+//
+// The keywords are normalized to the semantics of Draft202012 whichever draft
+// the document is written in: a draft-04 `maximum` with a boolean
+// `exclusiveMaximum` is held in ExclusiveMaximum, an `items` array is held in
+// PrefixItems, and `dependencies` is split into DependentRequired and
+// DependentSchemas.
+type Schema struct {
+	// Draft is the draft the schema is written in.
+	Draft Draft
+	// Location is the URI of the schema within the document such as "#/properties/id".
+	Location string
+	// ID is the resolved $id (or draft-04 id) of the schema.
+	ID string
+
+	// Boolean is not nil when the schema is the boolean schema true or false.
+	Boolean *bool
+
+	Ref string
+	ref *Schema
+	// DynamicRef is $dynamicRef, or $recursiveRef of Draft201909, which is
+	// resolved in the dynamic scope of the evaluation: it references the
+	// outermost schema with the same DynamicAnchor, or RecursiveAnchor, if
+	// the schema which it references statically has it.
+	DynamicRef      string
+	DynamicAnchor   string
+	RecursiveAnchor bool
+	dynamicRef      *Schema
+
+	Types    []string
+	Enum     []interface{}
+	Const    interface{}
+	HasConst bool
+
+	MultipleOf       *float64
+	Maximum          *float64
+	ExclusiveMaximum *float64
+	Minimum          *float64
+	ExclusiveMinimum *float64
+
+	MaxLength *int
+	MinLength *int
+	Pattern   string
+	Format    string
+
+	PrefixItems []*Schema
+	Items       *Schema
+	Contains    *Schema
+	MaxContains *int
+	MinContains *int
+	MaxItems    *int
+	MinItems    *int
+	UniqueItems bool
+	// UnevaluatedItems applies to the items which the other keywords of the
+	// schema and its in-place subschemas such as allOf don't evaluate.
+	UnevaluatedItems *Schema
+
+	Properties           map[string]*Schema
+	PatternProperties    map[string]*Schema
+	AdditionalProperties *Schema
+	PropertyNames        *Schema
+	Required             []string
+	DependentRequired    map[string][]string
+	DependentSchemas     map[string]*Schema
+	MaxProperties        *int
+	MinProperties        *int
+	// UnevaluatedProperties applies to the properties which the other
+	// keywords of the schema and its in-place subschemas don't evaluate.
+	UnevaluatedProperties *Schema
+
+	AllOf []*Schema
+	AnyOf []*Schema
+	OneOf []*Schema
+	Not   *Schema
+	If    *Schema
+	Then  *Schema
+	Else  *Schema
+
+	// Discriminator selects the branch of OneOf, or AnyOf if OneOf is absent,
+	// when the schema is compiled with Discriminator of Compiler.
+	Discriminator *Discriminator
+
+	// ErrorMessage replaces the messages of the errors of the schema.
+	ErrorMessage *ErrorMessage
+
+	Defs map[string]*Schema
+
+	Title       string
+	Description string
+	Default     interface{}
+	HasDefault  bool
+
+	// Extra holds the keywords which are not known to the draft.
+	Extra map[string]interface{}
+
+	maxLength         *strings.MaxLengthValidator
+	minLength         *strings.MinLengthValidator
+	pattern           *strings.PatternValidator
+	format            *strings.FormatValidator
+	maximum           []numbers.MaximumValidator
+	minimum           []numbers.MinimumValidator
+	exactMultipleOf   *bignumbers.MultipleOfValidator
+	exactMaximum      []bignumbers.MaximumValidator
+	exactMinimum      []bignumbers.MinimumValidator
+	maxItems          *arrays.MaxItemsValidator
+	minItems          *arrays.MinItemsValidator
+	patternProperties map[string]*regexp.Regexp
+	custom            []customKeyword
+	limits            Limits
+	// base is the URI of the resource of the schema, and anchors is the
+	// schemas of the dynamic anchors of its compilation by base#anchor.
+	base    string
+	anchors map[string]*Schema
+}
+
+// Discriminator is the discriminator of OpenAPI, which selects the branch
+// to validate an object by the value of its property, instead of trying all
+// the branches.
+type Discriminator struct {
+	PropertyName string
+	// Mapping is the branches by the values of the property. The branches
+	// which are $ref are also mapped by the names at the end of the
+	// references, such as "Dog" of "#/components/schemas/Dog".
+	Mapping map[string]*Schema
+
+	// anyOf reports whether the branches are of AnyOf.
+	anyOf bool
+}
+
+// Validate returns whether instance is valid against s.
+// The instance should be a value decoded by encoding/json.
+// The returned error is ValidationErrors which has all the errors found.
+// The compiled schema is not modified, so Validate is safe for concurrent use.
+func (s *Schema) Validate(instance interface{}) error {
+	errs := s.validate(nil, instance, "")
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// RefSchema returns the schema referenced by $ref, or nil if s has no $ref.
+func (s *Schema) RefSchema() *Schema {
+	return s.ref
+}
@@ -0,0 +1,454 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+type ValidateTestCase struct {
+	Message  string
+	Instance string
+	Errors   []string
+}
+
+func testValidate(t *testing.T, document string, cases []ValidateTestCase) {
+	testValidateWithCompiler(t, schema.NewCompiler(), document, cases)
+}
+
+func testValidateWithCompiler(t *testing.T, compiler *schema.Compiler, document string, cases []ValidateTestCase) {
+	s, err := compiler.Load([]byte(document))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	for _, c := range cases {
+		var instance interface{}
+		if err := json.Unmarshal([]byte(c.Instance), &instance); err != nil {
+			t.Fatalf("Test with %s: fail to decode instance: %s", c.Message, err)
+		}
+		var actual []string
+		if errs, ok := s.Validate(instance).(schema.ValidationErrors); ok {
+			for _, e := range errs {
+				actual = append(actual, e.InstancePath+" "+e.Keyword)
+			}
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+}
+
+func TestValidateOfSchemaWithExclusiveMaximumInDraft04(t *testing.T) {
+	testValidate(t, `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"maximum": 10,
+		"exclusiveMaximum": true
+	}`, []ValidateTestCase{
+		{Message: "less number", Instance: `9`},
+		{Message: "same number", Instance: `10`, Errors: []string{" exclusiveMaximum"}},
+		{Message: "non number", Instance: `"10"`},
+	})
+}
+
+func TestValidateOfSchemaWithExclusiveMaximumInDraft07(t *testing.T) {
+	testValidate(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"maximum": 10,
+		"exclusiveMaximum": 5
+	}`, []ValidateTestCase{
+		{Message: "less number", Instance: `4`},
+		{Message: "same number as exclusive", Instance: `5`, Errors: []string{" exclusiveMaximum"}},
+		{Message: "greater number", Instance: `11`, Errors: []string{" maximum", " exclusiveMaximum"}},
+	})
+}
+
+func TestValidateOfSchemaWithItemsInDraft07(t *testing.T) {
+	testValidate(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"items": [{"type": "integer"}, {"type": "string"}],
+		"additionalItems": false
+	}`, []ValidateTestCase{
+		{Message: "matching items", Instance: `[1, "a"]`},
+		{Message: "fewer items", Instance: `[1]`},
+		{Message: "mismatched item", Instance: `["a", "a"]`, Errors: []string{"/0 type"}},
+		{Message: "additional item", Instance: `[1, "a", 2]`, Errors: []string{"/2 "}},
+	})
+}
+
+func TestValidateOfSchemaWithPrefixItemsIn202012(t *testing.T) {
+	testValidate(t, `{
+		"prefixItems": [{"type": "integer"}],
+		"items": {"type": "string"}
+	}`, []ValidateTestCase{
+		{Message: "matching items", Instance: `[1, "a", "b"]`},
+		{Message: "mismatched item", Instance: `[1, 2]`, Errors: []string{"/1 type"}},
+	})
+}
+
+func TestValidateOfSchemaWithDependencies(t *testing.T) {
+	document := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"dependencies": {
+			"credit_card": ["billing_address"],
+			"name": {"required": ["email"]}
+		}
+	}`
+	testValidate(t, document, []ValidateTestCase{
+		{Message: "no dependency", Instance: `{}`},
+		{Message: "satisfied dependencies", Instance: `{"credit_card": 1, "billing_address": "a", "name": "a", "email": "a"}`},
+		{Message: "missing required dependency", Instance: `{"credit_card": 1}`, Errors: []string{" dependentRequired"}},
+		{Message: "missing schema dependency", Instance: `{"name": "a"}`, Errors: []string{" required"}},
+	})
+}
+
+func TestValidateOfSchemaWithKeywords(t *testing.T) {
+	testValidate(t, `{
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string", "minLength": 1, "maxLength": 5},
+			"email": {"format": "email"},
+			"tags": {"type": "array", "maxItems": 2, "uniqueItems": true, "items": {"enum": ["a", "b", "c"]}},
+			"kind": {"const": "user"}
+		},
+		"patternProperties": {"^x-": {"type": "string"}},
+		"additionalProperties": false
+	}`, []ValidateTestCase{
+		{Message: "valid object", Instance: `{"id": 1, "name": "foo", "email": "foo@example.com", "tags": ["a", "b"], "kind": "user", "x-a": "b"}`},
+		{Message: "not object", Instance: `[]`, Errors: []string{" type"}},
+		{Message: "missing required", Instance: `{"id": 1}`, Errors: []string{" required"}},
+		{Message: "invalid properties", Instance: `{"id": 0, "name": "foobar"}`, Errors: []string{"/id minimum", "/name maxLength"}},
+		{Message: "invalid format", Instance: `{"id": 1, "name": "foo", "email": "foo"}`, Errors: []string{"/email format"}},
+		{Message: "invalid items", Instance: `{"id": 1, "name": "foo", "tags": ["a", "a", "d"]}`, Errors: []string{"/tags maxItems", "/tags uniqueItems", "/tags/2 enum"}},
+		{Message: "invalid const", Instance: `{"id": 1, "name": "foo", "kind": "admin"}`, Errors: []string{"/kind const"}},
+		{Message: "invalid pattern property", Instance: `{"id": 1, "name": "foo", "x-a": 1}`, Errors: []string{"/x-a type"}},
+		{Message: "additional property", Instance: `{"id": 1, "name": "foo", "age": 1}`, Errors: []string{" additionalProperties"}},
+	})
+}
+
+func TestValidateOfSchemaWithCombinators(t *testing.T) {
+	testValidate(t, `{
+		"anyOf": [{"type": "string"}, {"type": "number"}],
+		"oneOf": [{"maxLength": 3}, {"minimum": 0}],
+		"not": {"const": "bad"}
+	}`, []ValidateTestCase{
+		{Message: "string", Instance: `"foo"`, Errors: []string{" oneOf"}},
+		{Message: "long string", Instance: `"foobar"`},
+		{Message: "number", Instance: `-1`},
+		{Message: "neither", Instance: `true`, Errors: []string{" anyOf", " oneOf"}},
+		{Message: "not", Instance: `"bad"`, Errors: []string{" oneOf", " not"}},
+	})
+}
+
+func TestValidateOfSchemaWithDiscriminator(t *testing.T) {
+	document := `{
+		"oneOf": [
+			{"$ref": "#/components/schemas/Created"},
+			{"$ref": "#/components/schemas/Deleted"},
+			{"$ref": "#/components/schemas/Renamed"}
+		],
+		"discriminator": {
+			"propertyName": "type",
+			"mapping": {"user.created": "Created", "user.deleted": "#/components/schemas/Deleted"}
+		},
+		"components": {
+			"schemas": {
+				"Created": {"properties": {"type": {"type": "string"}, "name": {"type": "string"}}, "required": ["name"]},
+				"Deleted": {"properties": {"type": {"type": "string"}, "id": {"type": "integer"}}, "required": ["id"]},
+				"Renamed": {"properties": {"type": {"type": "string"}, "name": {"type": "string"}}, "required": ["name"]}
+			}
+		}
+	}`
+	compiler := schema.NewCompiler()
+	compiler.Discriminator = true
+	testValidateWithCompiler(t, compiler, document, []ValidateTestCase{
+		{Message: "mapping by name", Instance: `{"type": "user.created", "name": "foo"}`},
+		{Message: "mapping by reference", Instance: `{"type": "user.deleted", "id": 1}`},
+		{Message: "implicit mapping", Instance: `{"type": "Renamed", "name": "foo"}`},
+		{Message: "errors of the selected branch", Instance: `{"type": "user.deleted", "id": "1"}`, Errors: []string{"/id type"}},
+		{Message: "unknown value", Instance: `{"type": "user.updated", "name": "foo"}`, Errors: []string{" discriminator"}},
+		{Message: "missing property", Instance: `{"name": "foo"}`, Errors: []string{" discriminator"}},
+		{Message: "not an object", Instance: `1`, Errors: []string{" oneOf"}},
+	})
+	testValidate(t, document, []ValidateTestCase{
+		{Message: "discriminator is ignored by default", Instance: `{"type": "user.created", "name": "foo"}`, Errors: []string{" oneOf"}},
+	})
+
+	s, err := compiler.Load([]byte(document))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	err = s.Validate(map[string]interface{}{"type": "user.updated"})
+	expected := schema.ValidationErrors{
+		{
+			SchemaPath: "#/discriminator",
+			Keyword:    "discriminator",
+			Err: &schema.DiscriminatorValidationError{
+				PropertyName: "type",
+				Values:       []string{"Created", "Deleted", "Renamed", "user.created", "user.deleted"},
+				Input:        "user.updated",
+			},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestValidateOfSchemaWithRef(t *testing.T) {
+	testValidate(t, `{
+		"$id": "http://example.com/root.json",
+		"properties": {
+			"a": {"$ref": "#/definitions/positive"},
+			"b": {"$ref": "item.json"},
+			"c": {"$ref": "#node"},
+			"d": {"$ref": "#"}
+		},
+		"definitions": {
+			"positive": {"minimum": 0},
+			"item": {"$id": "item.json", "type": "string"},
+			"node": {"$anchor": "node", "type": "boolean"}
+		}
+	}`, []ValidateTestCase{
+		{Message: "valid", Instance: `{"a": 1, "b": "b", "c": true, "d": {"a": 0}}`},
+		{Message: "invalid", Instance: `{"a": -1, "b": 1, "c": 1, "d": {"a": -1}}`, Errors: []string{"/a minimum", "/b type", "/c type", "/d/a minimum"}},
+	})
+}
+
+func TestValidateOfSchemaWithRefSiblings(t *testing.T) {
+	document := `{
+		"definitions": {"string": {"type": "string"}},
+		"properties": {"a": {"$ref": "#/definitions/string", "maxLength": 2}}
+	}`
+	testValidate(t, `{"$schema": "http://json-schema.org/draft-07/schema#", `+document[1:], []ValidateTestCase{
+		{Message: "siblings are ignored in draft-07", Instance: `{"a": "foo"}`},
+	})
+	testValidate(t, document, []ValidateTestCase{
+		{Message: "siblings are evaluated in 2020-12", Instance: `{"a": "foo"}`, Errors: []string{"/a maxLength"}},
+	})
+}
+
+func TestValidateOfSchemaWithUnevaluated(t *testing.T) {
+	testValidate(t, `{
+		"allOf": [{"properties": {"a": true}}],
+		"anyOf": [{"properties": {"b": true}, "required": ["b"]}, {"required": ["c"]}],
+		"unevaluatedProperties": false
+	}`, []ValidateTestCase{
+		{Message: "evaluated properties", Instance: `{"a": 1, "b": 2}`},
+		{Message: "property of the failed branch", Instance: `{"a": 1, "c": 3}`, Errors: []string{" unevaluatedProperties"}},
+		{Message: "unevaluated property", Instance: `{"b": 2, "d": 4}`, Errors: []string{" unevaluatedProperties"}},
+	})
+	testValidate(t, `{
+		"prefixItems": [{"type": "string"}],
+		"contains": {"type": "boolean"},
+		"unevaluatedItems": {"type": "integer"}
+	}`, []ValidateTestCase{
+		{Message: "evaluated items", Instance: `["a", true, 1]`},
+		{Message: "unevaluated item", Instance: `["a", true, null]`, Errors: []string{"/2 type"}},
+	})
+}
+
+func TestValidateOfSchemaWithDynamicRef(t *testing.T) {
+	testValidate(t, `{
+		"$id": "https://example.com/strict-tree",
+		"$dynamicAnchor": "node",
+		"$ref": "tree",
+		"unevaluatedProperties": false,
+		"$defs": {
+			"tree": {
+				"$id": "tree",
+				"$dynamicAnchor": "node",
+				"type": "object",
+				"properties": {
+					"data": true,
+					"children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+				}
+			}
+		}
+	}`, []ValidateTestCase{
+		{Message: "valid tree", Instance: `{"children": [{"data": 1, "children": [{"data": 2}]}]}`},
+		{Message: "unknown property in the children", Instance: `{"children": [{"children": [{"datum": 2}]}]}`, Errors: []string{
+			// the annotations of the invalid children are dropped, so their properties are unevaluated too
+			"/children/0/children/0 unevaluatedProperties", "/children/0 unevaluatedProperties", " unevaluatedProperties",
+		}},
+	})
+	testValidate(t, `{
+		"$schema": "https://json-schema.org/draft/2019-09/schema",
+		"$id": "https://example.com/strict-tree",
+		"$recursiveAnchor": true,
+		"$ref": "tree",
+		"unevaluatedProperties": false,
+		"$defs": {
+			"tree": {
+				"$id": "tree",
+				"$recursiveAnchor": true,
+				"type": "object",
+				"properties": {
+					"data": true,
+					"children": {"type": "array", "items": {"$recursiveRef": "#"}}
+				}
+			}
+		}
+	}`, []ValidateTestCase{
+		{Message: "valid tree", Instance: `{"children": [{"data": 1, "children": [{"data": 2}]}]}`},
+		{Message: "unknown property in the children", Instance: `{"children": [{"children": [{"datum": 2}]}]}`, Errors: []string{
+			// the annotations of the invalid children are dropped, so their properties are unevaluated too
+			"/children/0/children/0 unevaluatedProperties", "/children/0 unevaluatedProperties", " unevaluatedProperties",
+		}},
+	})
+}
+
+func TestValidateOfSchemaWithValidatorErrors(t *testing.T) {
+	s, err := schema.Load([]byte(`{"properties": {"a": {"maxLength": 2}, "b": {"exclusiveMaximum": 1}}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	err = s.Validate(map[string]interface{}{"a": "foo", "b": 1.0})
+	expected := schema.ValidationErrors{
+		{
+			InstancePath: "/a",
+			SchemaPath:   "#/properties/a/maxLength",
+			Keyword:      "maxLength",
+			Err: &strings.MaxLengthValidationError{
+				Definition: strings.MaxLengthValidatorDefinition{MaxLength: 2},
+				Input:      "foo",
+			},
+		},
+		{
+			InstancePath: "/b",
+			SchemaPath:   "#/properties/b/exclusiveMaximum",
+			Keyword:      "exclusiveMaximum",
+			Err: &numbers.MaximumValidationError{
+				Definition: numbers.MaximumValidatorDefinition{Maximum: 1, Exclusive: true},
+				Input:      1,
+			},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	s, err := schema.Load([]byte(`{"properties": {"a": {"maxLength": 2}}, "required": ["b"]}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	errs := s.Validate(map[string]interface{}{"a": "foo"}).(schema.ValidationErrors)
+	localized := errs.Localize(messages.Japanese)
+	expected := "(root): プロパティ 'b' は必須です\n/a: 2 文字以下である必要がありますが、3 文字あります"
+	if localized.Error() != expected {
+		t.Errorf("expected %q, but actual %q", expected, localized.Error())
+	}
+	for i, e := range localized {
+		if e.InstancePath != errs[i].InstancePath || e.Keyword != errs[i].Keyword || e.Err != errs[i].Err {
+			t.Errorf("expected the fields of %v to be unchanged, but actual %v", errs[i], e)
+		}
+	}
+	expected = "(root): the property 'b' is required\n/a: should be less than, or equal to, 2 characters but actual value has 3 characters"
+	if errs.Error() != expected {
+		t.Errorf("expected %q, but actual %q", expected, errs.Error())
+	}
+}
+
+func TestValidateOfExactNumbers(t *testing.T) {
+	s, err := schema.Load([]byte(`{
+		"properties": {
+			"id": {"type": "integer", "maximum": 9007199254740993},
+			"price": {"multipleOf": 0.01, "exclusiveMinimum": 0.1},
+			"code": {"enum": [18446744073709551615]},
+			"total": {"const": 100000000000000000001}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance map[string]interface{}
+		Errors   []string
+	}
+	cases := []Case{
+		{
+			Message: "valid json.Number",
+			Instance: map[string]interface{}{
+				"id":    json.Number("9007199254740993"),
+				"price": json.Number("123456789012345.67"),
+				"code":  json.Number("18446744073709551615"),
+				"total": json.Number("100000000000000000001"),
+			},
+		},
+		{
+			Message: "invalid json.Number which is valid as float64",
+			Instance: map[string]interface{}{
+				"id":    json.Number("9007199254740994"),
+				"price": json.Number("0.1"),
+				"code":  json.Number("18446744073709551614"),
+				"total": json.Number("100000000000000000000"),
+			},
+			Errors: []string{"/code enum", "/id maximum", "/price exclusiveMinimum", "/total const"},
+		},
+		{
+			Message: "non integer json.Number",
+			Instance: map[string]interface{}{
+				"id":    json.Number("9007199254740992.5"),
+				"price": json.Number("0.105"),
+			},
+			Errors: []string{"/id type", "/price multipleOf"},
+		},
+		{
+			Message: "Go integers and big numbers",
+			Instance: map[string]interface{}{
+				"id":    int64(9007199254740993),
+				"price": big.NewRat(11, 100),
+				"code":  uint64(18446744073709551615),
+				"total": new(big.Int).Add(new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil), big.NewInt(1)),
+			},
+		},
+		{
+			Message:  "float64",
+			Instance: map[string]interface{}{"id": 9007199254740992.0, "price": 0.3},
+		},
+		{
+			Message:  "float64 multipleOf",
+			Instance: map[string]interface{}{"price": 19.99},
+		},
+		{
+			Message:  "float64 multipleOf of the large value",
+			Instance: map[string]interface{}{"price": 123456789012345.67},
+		},
+		{
+			Message:  "invalid float64 multipleOf",
+			Instance: map[string]interface{}{"price": 19.995},
+			Errors:   []string{"/price multipleOf"},
+		},
+	}
+	for _, c := range cases {
+		var actual []string
+		if errs, ok := s.Validate(c.Instance).(schema.ValidationErrors); ok {
+			for _, e := range errs {
+				actual = append(actual, e.InstancePath+" "+e.Keyword)
+			}
+		}
+		sort.Strings(actual)
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+}
+
+func TestValidateOfBooleanSchema(t *testing.T) {
+	testValidate(t, `{"properties": {"a": true, "b": false}}`, []ValidateTestCase{
+		{Message: "true schema", Instance: `{"a": 1}`},
+		{Message: "false schema", Instance: `{"b": 1}`, Errors: []string{"/b "}},
+	})
+}
@@ -0,0 +1,452 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+)
+
+// errStopped stops the streaming at the first error of FailFast.
+var errStopped = errors.New("the streaming is stopped")
+
+// StreamValidator validates the JSON values read from a json.Decoder token by
+// token, so that a large document is validated without decoding it into
+// interface{} first. Call UseNumber of the decoder to compare the numbers
+// exactly.
+//
+// The objects and arrays are streamed for type, properties,
+// patternProperties, additionalProperties, propertyNames, required,
+// dependentRequired, maxProperties, minProperties, prefixItems, items,
+// maxItems and minItems, through $ref and allOf. The other values are decoded
+// and validated like Validate: the strings, numbers, booleans and null, and
+// the objects and arrays whose schemas have the keywords which need the whole
+// value, such as enum, const, uniqueItems, contains, anyOf, oneOf, not, if,
+// dependentSchemas, unevaluatedItems, unevaluatedProperties, $dynamicRef,
+// errorMessage and the custom keywords. So the memory is bounded by the
+// largest of those values rather than by the document.
+//
+// The errors are reported in the order they are found, with Offset, which is
+// the byte offset of the value in the input, or of the decoded value which
+// contains it. The Input of the errors of the streamed objects and arrays is
+// StreamedValue, since they are not decoded.
+type StreamValidator struct {
+	Schema *Schema
+	// FailFast stops the validation at the first error, leaving the rest
+	// of the value unread.
+	FailFast bool
+}
+
+// Validate validates the next JSON value of d. It returns io.EOF when there
+// is no more value, the error of d when the input is not valid JSON, and
+// ValidationErrors otherwise.
+func (v *StreamValidator) Validate(d *json.Decoder) error {
+	st := &stream{d: d, failFast: v.FailFast}
+	if err := st.value([]*Schema{v.Schema}, ""); err != nil && err != errStopped {
+		return err
+	}
+	if len(st.errs) > 0 {
+		return st.errs
+	}
+	return nil
+}
+
+// StreamedValue is the Input of the errors of the objects and arrays which
+// StreamValidator streams. It's written as its type, with the number of the
+// items for maxItems and minItems.
+type StreamedValue struct {
+	Type  string `json:"type"`
+	Items *int   `json:"items,omitempty"`
+}
+
+func (v StreamedValue) String() string {
+	if v.Items != nil {
+		return fmt.Sprintf("%s of %d items", v.Type, *v.Items)
+	}
+	return v.Type
+}
+
+type stream struct {
+	d        *json.Decoder
+	failFast bool
+	errs     ValidationErrors
+}
+
+// fail adds the error of the keyword of s.
+func (st *stream) fail(s *Schema, path, keyword string, offset int64, err error) error {
+	location := s.Location
+	if keyword != "" {
+		location += "/" + EscapePointer(keyword)
+	}
+	return st.add(ValidationErrors{{
+		InstancePath: path,
+		SchemaPath:   location,
+		Keyword:      keyword,
+		Err:          err,
+	}}, offset)
+}
+
+// add adds errs at the offset, and returns errStopped when FailFast.
+func (st *stream) add(errs ValidationErrors, offset int64) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	if st.failFast {
+		errs = errs[:1]
+	}
+	for _, e := range errs {
+		e.Offset = offset
+	}
+	st.errs = append(st.errs, errs...)
+	if st.failFast {
+		return errStopped
+	}
+	return nil
+}
+
+// offset returns the offset of the next token, skipping the white spaces and
+// the separators which the decoder has buffered.
+func (st *stream) offset() int64 {
+	offset := st.d.InputOffset()
+	r, ok := st.d.Buffered().(io.ByteReader)
+	if !ok {
+		return offset
+	}
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return offset
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+}
+
+// value validates the next value of the decoder against all the schemas ss.
+func (st *stream) value(ss []*Schema, path string) error {
+	offset := st.offset()
+	tok, err := st.d.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return st.validate(ss, tok, path, offset)
+	}
+	expanded := expand(ss, nil, map[*Schema]bool{})
+	for _, s := range expanded {
+		if s.needsValue() {
+			v, err := st.decode(delim)
+			if err != nil {
+				return err
+			}
+			return st.validate(ss, v, path, offset)
+		}
+	}
+	if delim == '{' {
+		return st.object(expanded, path, offset)
+	}
+	return st.array(expanded, path, offset)
+}
+
+// validate validates the decoded value v like Validate.
+func (st *stream) validate(ss []*Schema, v interface{}, path string, offset int64) error {
+	for _, s := range ss {
+		if err := st.add(s.validate(nil, v, path), offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expand returns the schemas which apply to the same value as ss through
+// $ref and allOf. The true schemas are omitted. The compiler rejects the
+// cycles of them, but the schemas being expanded in parents are skipped
+// anyway, so that a cycle never overflows the stack.
+func expand(ss []*Schema, expanded []*Schema, parents map[*Schema]bool) []*Schema {
+	for _, s := range ss {
+		if s.Boolean != nil {
+			if !*s.Boolean {
+				expanded = append(expanded, s)
+			}
+			continue
+		}
+		if parents[s] {
+			continue
+		}
+		parents[s] = true
+		if s.ref != nil {
+			expanded = expand([]*Schema{s.ref}, expanded, parents)
+		}
+		if s.ref == nil || s.Draft > Draft07 {
+			expanded = append(expanded, s)
+			expanded = expand(s.AllOf, expanded, parents)
+		}
+		delete(parents, s)
+	}
+	return expanded
+}
+
+// needsValue reports whether s has the keywords which need the whole value
+// of an object or an array. The schemas with the dynamic anchors need it
+// too, since the dynamic scope isn't kept across the streamed values.
+func (s *Schema) needsValue() bool {
+	return s.Enum != nil || s.HasConst || s.UniqueItems || s.Contains != nil ||
+		len(s.AnyOf) > 0 || len(s.OneOf) > 0 || s.Not != nil || s.If != nil ||
+		len(s.DependentSchemas) > 0 || s.Discriminator != nil || s.ErrorMessage != nil ||
+		len(s.custom) > 0 || s.UnevaluatedItems != nil || s.UnevaluatedProperties != nil ||
+		s.DynamicRef != "" || len(s.anchors) > 0
+}
+
+// container validates the type of the object or array of the type typ, and
+// returns the schemas which aren't false.
+func (st *stream) container(ss []*Schema, typ string, path string, offset int64) ([]*Schema, error) {
+	var rest []*Schema
+	for _, s := range ss {
+		if s.Boolean != nil {
+			if err := st.fail(s, path, "", offset, &FalseSchemaValidationError{StreamedValue{Type: typ}}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if len(s.Types) > 0 && !contains(s.Types, typ) {
+			if err := st.fail(s, path, "type", offset, &TypeValidationError{Types: s.Types, Input: StreamedValue{Type: typ}}); err != nil {
+				return nil, err
+			}
+		}
+		rest = append(rest, s)
+	}
+	return rest, nil
+}
+
+func (st *stream) object(ss []*Schema, path string, offset int64) error {
+	ss, err := st.container(ss, "object", path, offset)
+	if err != nil {
+		return err
+	}
+	// present holds the presence of the properties which required and
+	// dependentRequired refer, so that the other names aren't kept.
+	present := map[string]bool{}
+	for _, s := range ss {
+		for _, name := range s.Required {
+			present[name] = false
+		}
+		for key, names := range s.DependentRequired {
+			present[key] = false
+			for _, name := range names {
+				present[name] = false
+			}
+		}
+	}
+	n := 0
+	for st.d.More() {
+		keyOffset := st.offset()
+		tok, err := st.d.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		n++
+		if _, ok := present[key]; ok {
+			present[key] = true
+		}
+		p := path + "/" + EscapePointer(key)
+		var subs []*Schema
+		for _, s := range ss {
+			evaluated := false
+			if sub, ok := s.Properties[key]; ok {
+				evaluated = true
+				subs = append(subs, sub)
+			}
+			for _, pattern := range sortedKeys(s.PatternProperties) {
+				if s.patternProperties[pattern].MatchString(key) {
+					evaluated = true
+					subs = append(subs, s.PatternProperties[pattern])
+				}
+			}
+			if !evaluated && s.AdditionalProperties != nil {
+				if b := s.AdditionalProperties.Boolean; b != nil && !*b {
+					if err := st.fail(s, path, "additionalProperties", keyOffset, &AdditionalPropertiesValidationError{key}); err != nil {
+						return err
+					}
+				} else {
+					subs = append(subs, s.AdditionalProperties)
+				}
+			}
+			if s.PropertyNames != nil {
+				if err := st.add(s.PropertyNames.validate(nil, key, p), keyOffset); err != nil {
+					return err
+				}
+			}
+		}
+		if err := st.member(subs, p); err != nil {
+			return err
+		}
+	}
+	if _, err := st.d.Token(); err != nil {
+		return err
+	}
+
+	for _, s := range ss {
+		if s.MaxProperties != nil && n > *s.MaxProperties {
+			if err := st.fail(s, path, "maxProperties", offset, &MaxPropertiesValidationError{*s.MaxProperties, n}); err != nil {
+				return err
+			}
+		}
+		if s.MinProperties != nil && n < *s.MinProperties {
+			if err := st.fail(s, path, "minProperties", offset, &MinPropertiesValidationError{*s.MinProperties, n}); err != nil {
+				return err
+			}
+		}
+		for _, name := range s.Required {
+			if !present[name] {
+				if err := st.fail(s, path, "required", offset, &RequiredValidationError{s.Required, name}); err != nil {
+					return err
+				}
+			}
+		}
+		keys := make([]string, 0, len(s.DependentRequired))
+		for key := range s.DependentRequired {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !present[key] {
+				continue
+			}
+			required := s.DependentRequired[key]
+			for _, name := range required {
+				if !present[name] {
+					if err := st.fail(s, path, "dependentRequired", offset, &DependentRequiredValidationError{key, required, name}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (st *stream) array(ss []*Schema, path string, offset int64) error {
+	ss, err := st.container(ss, "array", path, offset)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for ; st.d.More(); n++ {
+		var subs []*Schema
+		for _, s := range ss {
+			if n < len(s.PrefixItems) {
+				subs = append(subs, s.PrefixItems[n])
+			} else if s.Items != nil {
+				subs = append(subs, s.Items)
+			}
+		}
+		if err := st.member(subs, path+"/"+strconv.Itoa(n)); err != nil {
+			return err
+		}
+	}
+	if _, err := st.d.Token(); err != nil {
+		return err
+	}
+
+	for _, s := range ss {
+		if s.MaxItems != nil && n > *s.MaxItems {
+			err := &arrays.MaxItemsValidationError{Definition: arrays.MaxItemsValidatorDefinition{MaxItems: *s.MaxItems}, Input: StreamedValue{"array", &n}}
+			if err := st.fail(s, path, "maxItems", offset, err); err != nil {
+				return err
+			}
+		}
+		if s.MinItems != nil && n < *s.MinItems {
+			err := &arrays.MinItemsValidationError{Definition: arrays.MinItemsValidatorDefinition{MinItems: *s.MinItems}, Input: StreamedValue{"array", &n}}
+			if err := st.fail(s, path, "minItems", offset, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// member validates the property or the item against ss, or skips it when no
+// schema applies.
+func (st *stream) member(ss []*Schema, path string) error {
+	if len(ss) > 0 {
+		return st.value(ss, path)
+	}
+	tok, err := st.d.Token()
+	if err != nil {
+		return err
+	}
+	if _, ok := tok.(json.Delim); ok {
+		return st.skip()
+	}
+	return nil
+}
+
+// decode returns the rest of the object or array which starts with delim.
+func (st *stream) decode(delim json.Delim) (interface{}, error) {
+	var a []interface{}
+	var m map[string]interface{}
+	if delim == '{' {
+		m = map[string]interface{}{}
+	} else {
+		a = []interface{}{}
+	}
+	for st.d.More() {
+		var key string
+		if m != nil {
+			tok, err := st.d.Token()
+			if err != nil {
+				return nil, err
+			}
+			key = tok.(string)
+		}
+		tok, err := st.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		v := interface{}(tok)
+		if delim, ok := tok.(json.Delim); ok {
+			if v, err = st.decode(delim); err != nil {
+				return nil, err
+			}
+		}
+		if m != nil {
+			m[key] = v
+		} else {
+			a = append(a, v)
+		}
+	}
+	if _, err := st.d.Token(); err != nil {
+		return nil, err
+	}
+	if m != nil {
+		return m, nil
+	}
+	return a, nil
+}
+
+// skip reads the rest of the object or array whose first token is read.
+func (st *stream) skip() error {
+	for depth := 1; depth > 0; {
+		tok, err := st.d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
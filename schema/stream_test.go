@@ -0,0 +1,234 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/messages"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const streamDocument = `{
+	"type": "object",
+	"required": ["id", "items"],
+	"additionalProperties": false,
+	"properties": {
+		"id": {"type": "integer", "maximum": 9007199254740993},
+		"items": {
+			"type": "array",
+			"maxItems": 2,
+			"items": {"$ref": "#/$defs/item"}
+		},
+		"tags": {"uniqueItems": true}
+	},
+	"$defs": {
+		"item": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {"name": {"type": "string", "maxLength": 3}},
+			"dependentRequired": {"price": ["currency"]}
+		}
+	}
+}`
+
+func streamErrors(err error) []string {
+	errs, ok := err.(schema.ValidationErrors)
+	if !ok {
+		return nil
+	}
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, fmt.Sprintf("%d %s %s", e.Offset, e.InstancePath, e.Keyword))
+	}
+	return actual
+}
+
+func TestStreamValidator(t *testing.T) {
+	s, err := schema.Load([]byte(streamDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+
+	type Case struct {
+		Message  string
+		Instance string
+		Errors   []string
+	}
+	cases := []Case{
+		{
+			Message:  "valid object",
+			Instance: `{"id": 9007199254740993, "items": [{"name": "foo"}], "tags": [1, 2]}`,
+		},
+		{
+			Message:  "invalid primitives",
+			Instance: `{"id": 9007199254740994, "items": [{"name": "foobar"}, {"name": 1}]}`,
+			Errors:   []string{"7 /id maximum", "44 /items/0/name maxLength", "64 /items/1/name type"},
+		},
+		{
+			Message:  "invalid object and array",
+			Instance: `{"items": [{"price": 1}, {"name": "a"}, {"name": "b"}], "extra": {"a": [1]}}`,
+			Errors: []string{
+				"11 /items/0 required",
+				"11 /items/0 dependentRequired",
+				"10 /items maxItems",
+				"56  additionalProperties",
+				"0  required",
+			},
+		},
+		{
+			Message:  "decoded value",
+			Instance: `{"id": 1, "items": [], "tags": [{"a": 1}, {"a": 1}]}`,
+			Errors:   []string{"31 /tags uniqueItems"},
+		},
+		{
+			Message:  "wrong type of container",
+			Instance: `[{"id": 1}]`,
+			Errors:   []string{"0  type"},
+		},
+	}
+	for _, c := range cases {
+		d := json.NewDecoder(strings.NewReader(c.Instance))
+		d.UseNumber()
+		v := &schema.StreamValidator{Schema: s}
+		actual := streamErrors(v.Validate(d))
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+}
+
+func TestStreamValidatorWithFailFast(t *testing.T) {
+	s, err := schema.Load([]byte(streamDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	d := json.NewDecoder(strings.NewReader(`{"id": "1", "items": 1} {"id": 1, "items": []}`))
+	d.UseNumber()
+	v := &schema.StreamValidator{Schema: s, FailFast: true}
+	if actual, expected := streamErrors(v.Validate(d)), []string{"7 /id type"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
+
+func TestStreamValidatorOfRecursiveSchema(t *testing.T) {
+	if _, err := schema.Load([]byte(`{"allOf": [{"$ref": "#"}]}`)); !errors.Is(err, schema.ReferenceCycleError) {
+		t.Fatalf("expected ReferenceCycleError, but actual %v", err)
+	}
+	s, err := schema.Load([]byte(treeDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	data, err := json.Marshal(tree(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := json.NewDecoder(strings.NewReader(strings.Replace(string(data), `"value":1`, `"value":"1"`, 1)))
+	d.UseNumber()
+	if actual := streamErrors((&schema.StreamValidator{Schema: s}).Validate(d)); len(actual) != 1 {
+		t.Errorf("expected the error of the value, but actual %v", actual)
+	}
+}
+
+func TestStreamValidatorOfValues(t *testing.T) {
+	s, err := schema.Load([]byte(streamDocument))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	d := json.NewDecoder(strings.NewReader(`{"id": 1, "items": []}
+{"id": 1}
+{"id": 1, "items": [}`))
+	d.UseNumber()
+	v := &schema.StreamValidator{Schema: s}
+
+	if err := v.Validate(d); err != nil {
+		t.Errorf("expected the first value to be valid, but actual %v", err)
+	}
+	if actual, expected := streamErrors(v.Validate(d)), []string{"23  required"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+	if err := v.Validate(d); err == nil || streamErrors(err) != nil {
+		t.Errorf("expected the syntax error, but actual %v", err)
+	}
+
+	d = json.NewDecoder(strings.NewReader(" "))
+	if err := v.Validate(d); err != io.EOF {
+		t.Errorf("expected %v, but actual %v", io.EOF, err)
+	}
+}
+
+func TestStreamValidatorMessages(t *testing.T) {
+	s, err := schema.Load([]byte(`{"properties": {"a": {"type": "string"}, "b": {"maxItems": 1}}}`))
+	if err != nil {
+		t.Fatalf("Fail to Load: %s", err)
+	}
+	d := json.NewDecoder(strings.NewReader(`{"a": {"x": 1}, "b": [1, 2]}`))
+	errs, _ := (&schema.StreamValidator{Schema: s}).Validate(d).(schema.ValidationErrors)
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, e.Message(messages.English))
+	}
+	expected := []string{
+		"input value object should be [string]",
+		"the length of array of 2 items should be less than or equal to 1",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
+
+func TestStreamValidatorAsValidate(t *testing.T) {
+	documents := []string{
+		streamDocument,
+		`{"$schema": "http://json-schema.org/draft-07/schema#", "properties": {"a": {"$ref": "#/definitions/a", "type": "string"}}, "definitions": {"a": {"minimum": 1}}}`,
+		`{"allOf": [{"minProperties": 2}, {"patternProperties": {"^x": {"type": "string"}}}], "propertyNames": {"maxLength": 2}}`,
+		`{"prefixItems": [{"type": "string"}], "items": false, "minItems": 2}`,
+		`{"oneOf": [{"required": ["a"]}, {"required": ["b"]}]}`,
+		`{"properties": {"items": {"items": {"$ref": "#/$defs/item"}}}, "$defs": {"item": {"properties": {"name": true}, "unevaluatedProperties": false}}}`,
+		`{"$dynamicAnchor": "a", "properties": {"items": {"items": {"$dynamicRef": "#a"}}}, "unevaluatedProperties": {"type": "integer"}}`,
+	}
+	instances := []string{
+		`{}`,
+		`[]`,
+		`{"a": 0, "xy": 1, "xyz": "z"}`,
+		`{"id": 1, "items": [{"name": "a", "price": 1}, {}, {}], "b": true}`,
+		`["a", 1, null]`,
+		`[1]`,
+		`{"a": 1, "b": 2}`,
+	}
+	for i, document := range documents {
+		s, err := schema.Load([]byte(document))
+		if err != nil {
+			t.Fatalf("Fail to Load: %s", err)
+		}
+		for _, instance := range instances {
+			var v interface{}
+			if err := json.Unmarshal([]byte(instance), &v); err != nil {
+				t.Fatal(err)
+			}
+			expected := keywords(s.Validate(v))
+			d := json.NewDecoder(strings.NewReader(instance))
+			d.UseNumber()
+			actual := keywords((&schema.StreamValidator{Schema: s}).Validate(d))
+			if !reflect.DeepEqual(actual, expected) {
+				t.Errorf("Test with document %d and %s: expected %v, but actual %v", i, instance, expected, actual)
+			}
+		}
+	}
+}
+
+// keywords returns the sorted instance paths, keywords and schema paths of err.
+func keywords(err error) []string {
+	errs, _ := err.(schema.ValidationErrors)
+	var actual []string
+	for _, e := range errs {
+		actual = append(actual, e.InstancePath+" "+e.Keyword+" "+e.SchemaPath)
+	}
+	sort.Strings(actual)
+	return actual
+}
@@ -0,0 +1,204 @@
+package schema_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+const (
+	suiteDir           = "testdata/JSON-Schema-Test-Suite/tests"
+	suiteKnownFailures = "testdata/suite_known_failures.txt"
+)
+
+var (
+	suiteUpdate = flag.Bool("suite.update", false, "rewrite the known failures of the JSON-Schema-Test-Suite")
+	suiteReport = flag.String("suite.report", "", "write the per-keyword report of the JSON-Schema-Test-Suite to the file")
+)
+
+var suiteDrafts = []struct {
+	dir   string
+	draft schema.Draft
+}{
+	{"draft4", schema.Draft04},
+	{"draft6", schema.Draft06},
+	{"draft7", schema.Draft07},
+	{"draft2019-09", schema.Draft201909},
+	{"draft2020-12", schema.Draft202012},
+}
+
+type suiteGroup struct {
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema"`
+	Tests       []struct {
+		Description string          `json:"description"`
+		Data        json.RawMessage `json:"data"`
+		Valid       bool            `json:"valid"`
+	} `json:"tests"`
+}
+
+// suiteResult counts the results of a keyword, which is a file of the suite.
+type suiteResult struct {
+	Passed int
+	Failed int
+	Known  int
+}
+
+// TestSuite runs the JSON-Schema-Test-Suite.
+// The failures listed in the known failures don't fail the test, but the
+// listed tests which pass do, so that the list is kept up to date.
+func TestSuite(t *testing.T) {
+	known, err := readKnownFailures(suiteKnownFailures)
+	if err != nil {
+		t.Fatalf("Fail to read known failures: %s", err)
+	}
+
+	results := map[string]*suiteResult{}
+	var failures []string
+	for _, d := range suiteDrafts {
+		files, err := filepath.Glob(filepath.Join(suiteDir, d.dir, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			keyword := d.dir + "/" + filepath.Base(file)
+			total, failed := runSuiteFile(t, file, keyword, d.draft)
+			result := &suiteResult{Passed: total - len(failed)}
+			results[keyword] = result
+			for _, name := range failed {
+				if known[name] {
+					result.Known++
+					delete(known, name)
+				} else if !*suiteUpdate {
+					t.Errorf("%s: unexpected failure", name)
+				}
+				result.Failed++
+				failures = append(failures, name)
+			}
+		}
+	}
+	if *suiteUpdate {
+		if err := writeKnownFailures(suiteKnownFailures, failures); err != nil {
+			t.Fatalf("Fail to write known failures: %s", err)
+		}
+	} else {
+		for name := range known {
+			t.Errorf("%s: passes but is listed in %s", name, suiteKnownFailures)
+		}
+	}
+
+	report := formatSuiteReport(results)
+	t.Log("\n" + report)
+	if *suiteReport != "" {
+		if err := ioutil.WriteFile(*suiteReport, []byte(report), 0644); err != nil {
+			t.Fatalf("Fail to write report: %s", err)
+		}
+	}
+}
+
+// runSuiteFile runs the tests in file and returns the number of the tests
+// and the names of the failed tests.
+func runSuiteFile(t *testing.T, file, keyword string, draft schema.Draft) (int, []string) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var groups []suiteGroup
+	if err := json.Unmarshal(data, &groups); err != nil {
+		t.Fatalf("Fail to decode %s: %s", file, err)
+	}
+
+	total := 0
+	var failures []string
+	for _, g := range groups {
+		compiler := schema.NewCompiler()
+		compiler.Draft = draft
+		compiler.Loader = schema.DirLoader{"http://localhost:1234/": filepath.Join(suiteDir, "..", "remotes")}
+		s, err := compiler.Load(g.Schema)
+		total += len(g.Tests)
+		for _, c := range g.Tests {
+			name := keyword + " > " + g.Description + " > " + c.Description
+			if err != nil {
+				failures = append(failures, name)
+				continue
+			}
+			instance, derr := decodeInstance(c.Data)
+			if derr != nil {
+				t.Fatalf("%s: fail to decode data: %s", name, derr)
+			}
+			if valid := s.Validate(instance) == nil; valid != c.Valid {
+				failures = append(failures, name)
+			}
+		}
+	}
+	return total, failures
+}
+
+func decodeInstance(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	err := d.Decode(&v)
+	return v, err
+}
+
+func readKnownFailures(file string) (map[string]bool, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	known := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		known[line] = true
+	}
+	return known, scanner.Err()
+}
+
+func writeKnownFailures(file string, failures []string) error {
+	var b bytes.Buffer
+	b.WriteString("# The tests of the JSON-Schema-Test-Suite which are known to fail.\n")
+	b.WriteString("# Generated by `go test ./schema -run TestSuite -suite.update`.\n")
+	sort.Strings(failures)
+	for _, name := range failures {
+		b.WriteString(name + "\n")
+	}
+	return ioutil.WriteFile(file, b.Bytes(), 0644)
+}
+
+func formatSuiteReport(results map[string]*suiteResult) string {
+	keywords := make([]string, 0, len(results))
+	for keyword := range results {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%-40s %6s %6s %6s\n", "keyword", "passed", "failed", "known")
+	var total suiteResult
+	for _, keyword := range keywords {
+		r := results[keyword]
+		fmt.Fprintf(&b, "%-40s %6d %6d %6d\n", keyword, r.Passed, r.Failed, r.Known)
+		total.Passed += r.Passed
+		total.Failed += r.Failed
+		total.Known += r.Known
+	}
+	fmt.Fprintf(&b, "%-40s %6d %6d %6d\n", "total", total.Passed, total.Failed, total.Known)
+	return b.String()
+}
@@ -0,0 +1,517 @@
+package schema
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+)
+
+// validate returns the errors of v against s.
+// The path is the JSON Pointer of v in the instance, and ev is the state of
+// ValidateContext, which is nil for Validate.
+func (s *Schema) validate(ev *evaluation, v interface{}, path string) ValidationErrors {
+	return s.eval(ev, nil, v, path, nil)
+}
+
+// scope is the dynamic scope of the evaluation, which is the bases of the
+// resources entered from the innermost, to resolve the dynamic references.
+type scope struct {
+	parent *scope
+	base   string
+}
+
+// annotationSet is the properties and the items which the keywords have
+// evaluated, for unevaluatedProperties and unevaluatedItems.
+type annotationSet struct {
+	properties map[string]bool
+	// items is the number of the leading items evaluated, or all of them.
+	items    int
+	allItems bool
+	contains map[int]bool
+}
+
+func (a *annotationSet) property(key string) {
+	if a.properties == nil {
+		a.properties = map[string]bool{}
+	}
+	a.properties[key] = true
+}
+
+func (a *annotationSet) merge(b *annotationSet) {
+	for key := range b.properties {
+		a.property(key)
+	}
+	if b.items > a.items {
+		a.items = b.items
+	}
+	a.allItems = a.allItems || b.allItems
+	for i := range b.contains {
+		if a.contains == nil {
+			a.contains = map[int]bool{}
+		}
+		a.contains[i] = true
+	}
+}
+
+// eval returns the errors of v against s in the dynamic scope sc. The
+// properties and the items which s evaluates are recorded in ann unless it
+// is nil.
+func (s *Schema) eval(ev *evaluation, sc *scope, v interface{}, path string, ann *annotationSet) ValidationErrors {
+	if !ev.enter(path) {
+		return nil
+	}
+	defer ev.leave()
+
+	var errs ValidationErrors
+	fail := func(keyword string, err error) {
+		errs = append(errs, &ValidationError{
+			InstancePath: path,
+			SchemaPath:   s.Location + "/" + EscapePointer(keyword),
+			Keyword:      keyword,
+			Err:          err,
+		})
+	}
+
+	if s.Boolean != nil {
+		if *s.Boolean {
+			return nil
+		}
+		return ValidationErrors{{
+			InstancePath: path,
+			SchemaPath:   s.Location,
+			Err:          &FalseSchemaValidationError{v},
+		}}
+	}
+
+	if len(s.anchors) > 0 && (sc == nil || sc.base != s.base) {
+		sc = &scope{sc, s.base}
+	}
+	if ann == nil && (s.UnevaluatedItems != nil || s.UnevaluatedProperties != nil) {
+		ann = &annotationSet{}
+	}
+	if s.ref != nil {
+		errs = append(errs, s.ref.apply(ev, sc, v, path, ann)...)
+		if s.Draft <= Draft07 {
+			return errs
+		}
+	}
+	if s.dynamicRef != nil {
+		errs = append(errs, s.dynamicTarget(sc).apply(ev, sc, v, path, ann)...)
+	}
+
+	if len(s.Types) > 0 {
+		ok := false
+		for _, t := range s.Types {
+			if hasType(v, t) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			fail("type", &TypeValidationError{s.Types, v})
+		}
+	}
+	if s.Enum != nil {
+		ok := false
+		for _, e := range s.Enum {
+			if equal(v, e) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			fail("enum", &EnumValidationError{s.Enum, v})
+		}
+	}
+	if s.HasConst && !equal(v, s.Const) {
+		fail("const", &ConstValidationError{s.Const, v})
+	}
+
+	switch t := v.(type) {
+	case string:
+		s.validateString(t, fail)
+	case []interface{}:
+		e := s.validateArray(ev, sc, t, path, ann, fail)
+		errs = append(errs, e...)
+	case map[string]interface{}:
+		e := s.validateObject(ev, sc, t, path, ann, fail)
+		errs = append(errs, e...)
+	default:
+		if _, ok := exactRat(v); ok {
+			s.validateExactNumber(v, fail)
+		} else if f, ok := toFloat(v); ok {
+			s.validateNumber(f, fail)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		errs = append(errs, sub.apply(ev, sc, v, path, ann)...)
+	}
+	anyOf, oneOf := s.AnyOf, s.OneOf
+	if d := s.Discriminator; d != nil {
+		if m, ok := v.(map[string]interface{}); ok {
+			value, _ := m[d.PropertyName].(string)
+			if sub, ok := d.Mapping[value]; ok {
+				errs = append(errs, sub.apply(ev, sc, v, path, ann)...)
+			} else {
+				fail("discriminator", &DiscriminatorValidationError{d.PropertyName, d.values(), m[d.PropertyName]})
+			}
+			if d.anyOf {
+				anyOf = nil
+			} else {
+				oneOf = nil
+			}
+		}
+	}
+	if anyOf != nil {
+		var suberrs []ValidationErrors
+		matched := false
+		for _, sub := range anyOf {
+			e := sub.apply(ev, sc, v, path, ann)
+			if len(e) == 0 {
+				matched = true
+				// the other branches are evaluated only for their annotations
+				if ann == nil {
+					break
+				}
+				continue
+			}
+			suberrs = append(suberrs, e)
+		}
+		if !matched {
+			fail("anyOf", &AnyOfValidationError{suberrs})
+		}
+	}
+	if oneOf != nil {
+		var suberrs []ValidationErrors
+		matched := 0
+		for _, sub := range oneOf {
+			e := sub.apply(ev, sc, v, path, ann)
+			if len(e) == 0 {
+				matched++
+			}
+			suberrs = append(suberrs, e)
+		}
+		if matched != 1 {
+			fail("oneOf", &OneOfValidationError{matched, suberrs})
+		}
+	}
+	if s.Not != nil && len(s.Not.eval(ev, sc, v, path, nil)) == 0 {
+		fail("not", &NotValidationError{v})
+	}
+	if s.If != nil {
+		if len(s.If.apply(ev, sc, v, path, ann)) == 0 {
+			if s.Then != nil {
+				errs = append(errs, s.Then.apply(ev, sc, v, path, ann)...)
+			}
+		} else if s.Else != nil {
+			errs = append(errs, s.Else.apply(ev, sc, v, path, ann)...)
+		}
+	}
+	if s.UnevaluatedItems != nil || s.UnevaluatedProperties != nil {
+		errs = append(errs, s.validateUnevaluated(ev, sc, v, path, ann, fail)...)
+	}
+	s.validateCustom(ev, v, path, fail)
+	if s.ErrorMessage != nil {
+		s.ErrorMessage.apply(s, errs, v, path)
+	}
+	return errs
+}
+
+// apply evaluates s in place of the schema which has it, such as allOf, and
+// merges its annotations into ann if v is valid against s.
+func (s *Schema) apply(ev *evaluation, sc *scope, v interface{}, path string, ann *annotationSet) ValidationErrors {
+	if ann == nil {
+		return s.eval(ev, sc, v, path, nil)
+	}
+	a := &annotationSet{}
+	errs := s.eval(ev, sc, v, path, a)
+	if len(errs) == 0 {
+		ann.merge(a)
+	}
+	return errs
+}
+
+// dynamicTarget returns the schema which DynamicRef of s references in the
+// dynamic scope sc. It is the outermost schema with the dynamic anchor in
+// sc if the schema which DynamicRef references statically has the anchor.
+func (s *Schema) dynamicTarget(sc *scope) *Schema {
+	target := s.dynamicRef
+	anchor := ""
+	if s.Draft == Draft201909 {
+		if !target.RecursiveAnchor {
+			return target
+		}
+	} else if _, anchor = splitFragment(s.DynamicRef); anchor == "" || anchor != target.DynamicAnchor {
+		return target
+	}
+	for ; sc != nil; sc = sc.parent {
+		if a, ok := s.anchors[sc.base+"#"+anchor]; ok {
+			target = a
+		}
+	}
+	return target
+}
+
+func (s *Schema) validateNumber(f float64, fail func(string, error)) {
+	if s.MultipleOf != nil && !s.isMultipleOf(f) {
+		fail("multipleOf", &MultipleOfValidationError{*s.MultipleOf, f})
+	}
+	for _, m := range s.maximum {
+		if err := m.Validate(f); err != nil {
+			if err.(*numbers.MaximumValidationError).Definition.Exclusive {
+				fail("exclusiveMaximum", err)
+			} else {
+				fail("maximum", err)
+			}
+		}
+	}
+	for _, m := range s.minimum {
+		if err := m.Validate(f); err != nil {
+			if err.(*numbers.MinimumValidationError).Definition.Exclusive {
+				fail("exclusiveMinimum", err)
+			} else {
+				fail("minimum", err)
+			}
+		}
+	}
+}
+
+// isMultipleOf reports whether f is a multiple of multipleOf. The finite f is
+// compared exactly in its shortest decimal form, which is how it's written in
+// JSON, so that 19.99 is a multiple of 0.01 like json.Number("19.99").
+func (s *Schema) isMultipleOf(f float64) bool {
+	if s.exactMultipleOf != nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return s.exactMultipleOf.Validate(json.Number(strconv.FormatFloat(f, 'g', -1, 64))) == nil
+	}
+	q := f / *s.MultipleOf
+	return !math.IsInf(q, 0) && q == math.Trunc(q)
+}
+
+// validateExactNumber validates the number v which is not a float, such as
+// json.Number and int64, comparing it exactly with the keywords.
+func (s *Schema) validateExactNumber(v interface{}, fail func(string, error)) {
+	if s.exactMultipleOf != nil {
+		if err := s.exactMultipleOf.Validate(v); err != nil {
+			fail("multipleOf", err)
+		}
+	}
+	for _, m := range s.exactMaximum {
+		if err := m.Validate(v); err != nil {
+			if err.(*bignumbers.MaximumValidationError).Definition.Exclusive {
+				fail("exclusiveMaximum", err)
+			} else {
+				fail("maximum", err)
+			}
+		}
+	}
+	for _, m := range s.exactMinimum {
+		if err := m.Validate(v); err != nil {
+			if err.(*bignumbers.MinimumValidationError).Definition.Exclusive {
+				fail("exclusiveMinimum", err)
+			} else {
+				fail("minimum", err)
+			}
+		}
+	}
+}
+
+func (s *Schema) validateString(str string, fail func(string, error)) {
+	if s.maxLength != nil {
+		if err := s.maxLength.Validate(str); err != nil {
+			fail("maxLength", err)
+		}
+	}
+	if s.minLength != nil {
+		if err := s.minLength.Validate(str); err != nil {
+			fail("minLength", err)
+		}
+	}
+	if s.pattern != nil {
+		if err := s.pattern.Validate(str); err != nil {
+			fail("pattern", err)
+		}
+	}
+	if s.format != nil {
+		if err := s.format.Validate(str); err != nil {
+			fail("format", err)
+		}
+	}
+}
+
+func (s *Schema) validateArray(ev *evaluation, sc *scope, a []interface{}, path string, ann *annotationSet, fail func(string, error)) ValidationErrors {
+	var errs ValidationErrors
+	if s.maxItems != nil {
+		if err := s.maxItems.Validate(a); err != nil {
+			fail("maxItems", err)
+		}
+	}
+	if s.minItems != nil {
+		if err := s.minItems.Validate(a); err != nil {
+			fail("minItems", err)
+		}
+	}
+	if s.UniqueItems {
+	unique:
+		for i := range a {
+			for j := i + 1; j < len(a); j++ {
+				if equal(a[i], a[j]) {
+					fail("uniqueItems", &UniqueItemsValidationError{a})
+					break unique
+				}
+			}
+		}
+	}
+	for i, item := range a {
+		p := path + "/" + strconv.Itoa(i)
+		if i < len(s.PrefixItems) {
+			errs = append(errs, s.PrefixItems[i].eval(ev, sc, item, p, nil)...)
+		} else if s.Items != nil {
+			errs = append(errs, s.Items.eval(ev, sc, item, p, nil)...)
+		}
+	}
+	if ann != nil {
+		if n := len(s.PrefixItems); n > ann.items {
+			ann.items = n
+		}
+		ann.allItems = ann.allItems || s.Items != nil
+	}
+	if s.Contains != nil {
+		matched := 0
+		for i, item := range a {
+			if len(s.Contains.eval(ev, sc, item, path+"/"+strconv.Itoa(i), nil)) == 0 {
+				matched++
+				if ann != nil && s.Draft >= Draft202012 {
+					if ann.contains == nil {
+						ann.contains = map[int]bool{}
+					}
+					ann.contains[i] = true
+				}
+			}
+		}
+		min := 1
+		if s.MinContains != nil {
+			min = *s.MinContains
+		}
+		if matched < min || (s.MaxContains != nil && matched > *s.MaxContains) {
+			fail("contains", &ContainsValidationError{min, s.MaxContains, matched})
+		}
+	}
+	return errs
+}
+
+func (s *Schema) validateObject(ev *evaluation, sc *scope, m map[string]interface{}, path string, ann *annotationSet, fail func(string, error)) ValidationErrors {
+	var errs ValidationErrors
+	if s.MaxProperties != nil && len(m) > *s.MaxProperties {
+		fail("maxProperties", &MaxPropertiesValidationError{*s.MaxProperties, len(m)})
+	}
+	if s.MinProperties != nil && len(m) < *s.MinProperties {
+		fail("minProperties", &MinPropertiesValidationError{*s.MinProperties, len(m)})
+	}
+	for _, name := range s.Required {
+		if _, ok := m[name]; !ok {
+			fail("required", &RequiredValidationError{s.Required, name})
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		v := m[key]
+		p := path + "/" + EscapePointer(key)
+		evaluated := false
+		if sub, ok := s.Properties[key]; ok {
+			evaluated = true
+			errs = append(errs, sub.eval(ev, sc, v, p, nil)...)
+		}
+		for _, pattern := range sortedKeys(s.PatternProperties) {
+			if s.patternProperties[pattern].MatchString(key) {
+				evaluated = true
+				errs = append(errs, s.PatternProperties[pattern].eval(ev, sc, v, p, nil)...)
+			}
+		}
+		if !evaluated && s.AdditionalProperties != nil {
+			evaluated = true
+			e := s.AdditionalProperties.eval(ev, sc, v, p, nil)
+			if s.AdditionalProperties.Boolean != nil && len(e) > 0 {
+				fail("additionalProperties", &AdditionalPropertiesValidationError{key})
+			} else {
+				errs = append(errs, e...)
+			}
+		}
+		if evaluated && ann != nil {
+			ann.property(key)
+		}
+		if s.PropertyNames != nil {
+			errs = append(errs, s.PropertyNames.eval(ev, sc, key, p, nil)...)
+		}
+		if required, ok := s.DependentRequired[key]; ok {
+			for _, name := range required {
+				if _, ok := m[name]; !ok {
+					fail("dependentRequired", &DependentRequiredValidationError{key, required, name})
+				}
+			}
+		}
+		if sub, ok := s.DependentSchemas[key]; ok {
+			errs = append(errs, sub.apply(ev, sc, m, path, ann)...)
+		}
+	}
+	return errs
+}
+
+// validateUnevaluated validates the items and the properties of v which
+// are not in ann against UnevaluatedItems and UnevaluatedProperties, and
+// records them in ann.
+func (s *Schema) validateUnevaluated(ev *evaluation, sc *scope, v interface{}, path string, ann *annotationSet, fail func(string, error)) ValidationErrors {
+	var errs ValidationErrors
+	switch t := v.(type) {
+	case []interface{}:
+		if s.UnevaluatedItems == nil || ann.allItems {
+			break
+		}
+		for i := ann.items; i < len(t); i++ {
+			if !ann.contains[i] {
+				errs = append(errs, s.UnevaluatedItems.eval(ev, sc, t[i], path+"/"+strconv.Itoa(i), nil)...)
+			}
+		}
+		ann.allItems = true
+	case map[string]interface{}:
+		if s.UnevaluatedProperties == nil {
+			break
+		}
+		keys := make([]string, 0, len(t))
+		for key := range t {
+			if !ann.properties[key] {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			e := s.UnevaluatedProperties.eval(ev, sc, t[key], path+"/"+EscapePointer(key), nil)
+			if s.UnevaluatedProperties.Boolean != nil && len(e) > 0 {
+				fail("unevaluatedProperties", &AdditionalPropertiesValidationError{key})
+			} else {
+				errs = append(errs, e...)
+			}
+			ann.property(key)
+		}
+	}
+	return errs
+}
+
+// values returns the sorted values of the mapping.
+func (d *Discriminator) values() []string {
+	values := make([]string, 0, len(d.Mapping))
+	for value := range d.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
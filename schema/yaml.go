@@ -0,0 +1,352 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	YAMLKeyError       = errors.New("the key of the mapping should be a string")
+	YAMLNumberError    = errors.New("the number should be finite")
+	YAMLAliasError     = errors.New("the alias shouldn't refer to the node which contains it")
+	YAMLMergeError     = errors.New("the value of the merge key should be a mapping or a sequence of mappings")
+	YAMLDocumentError  = errors.New("unexpected document after the first document")
+	YAMLExpansionError = errors.New("the aliases expand to too many nodes")
+)
+
+const (
+	// yamlExpansionRatio is the number of the nodes which a document may
+	// expand to by the aliases and the merge keys per node in it.
+	yamlExpansionRatio = 100
+	// yamlExpansionMinimum is the number of the nodes which any document may
+	// expand to.
+	yamlExpansionMinimum = 10000
+)
+
+// Position is the line and the column of a value in a YAML document, which
+// start at 1.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// YAMLError reports a YAML document which is not in the JSON data model,
+// such as a mapping with a non-string key.
+type YAMLError struct {
+	Line   int   `json:"line"`
+	Column int   `json:"column"`
+	Err    error `json:"error"`
+}
+
+func (e YAMLError) Error() string {
+	return fmt.Sprintf("yaml: line %d, column %d: %s", e.Line, e.Column, e.Err)
+}
+
+func (e YAMLError) Unwrap() error {
+	return e.Err
+}
+
+// YAMLDocument is a YAML document decoded into the values which Schema
+// validates, with the positions of the values by their JSON Pointers.
+type YAMLDocument struct {
+	Value     interface{}
+	Positions map[string]Position
+}
+
+// DecodeYAML decodes the YAML document in data like encoding/json with
+// UseNumber: the integers and the floats are json.Number of their decimal
+// forms, and the timestamps and the binaries are the strings as written.
+// The anchors, the aliases and the merge keys are expanded. The mappings
+// with the keys which are not strings, and the infinities and NaN, which
+// JSON doesn't have, are YAMLError. So are the documents whose aliases
+// expand to many times the nodes in them, such as billion laughs.
+func DecodeYAML(data []byte) (*YAMLDocument, error) {
+	return YAMLOptions{}.Decode(data)
+}
+
+// YAMLOptions are the options of the decoding of the YAML documents.
+type YAMLOptions struct {
+	// ScalarKeys allows the keys of the mappings which are integers, floats
+	// or booleans, and decodes them as the strings as written, such as the
+	// status codes of the responses of OpenAPI.
+	ScalarKeys bool
+}
+
+// Decode decodes the YAML document in data like DecodeYAML with o.
+func (o YAMLOptions) Decode(data []byte) (*YAMLDocument, error) {
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	var n yaml.Node
+	if err := d.Decode(&n); err != nil {
+		if err == io.EOF {
+			return &YAMLDocument{Positions: map[string]Position{}}, nil
+		}
+		return nil, err
+	}
+	var extra yaml.Node
+	if err := d.Decode(&extra); err != io.EOF {
+		if err != nil {
+			return nil, err
+		}
+		return nil, &YAMLError{extra.Line, extra.Column, YAMLDocumentError}
+	}
+	y := &yamlDecoder{
+		scalarKeys: o.ScalarKeys,
+		positions:  map[string]Position{},
+		aliases:    map[*yaml.Node]bool{},
+		budget:     yamlExpansionRatio * countYAMLNodes(&n),
+	}
+	if y.budget < yamlExpansionMinimum {
+		y.budget = yamlExpansionMinimum
+	}
+	v, err := y.decode(&n, "")
+	if err != nil {
+		return nil, err
+	}
+	return &YAMLDocument{v, y.positions}, nil
+}
+
+// Locate sets Line and Column of the ValidationErrors in err to the
+// positions of their values, and returns err.
+func (d *YAMLDocument) Locate(err error) error {
+	if errs, ok := err.(ValidationErrors); ok {
+		for _, e := range errs {
+			if p, ok := d.Positions[e.InstancePath]; ok {
+				e.Line, e.Column = p.Line, p.Column
+			}
+		}
+	}
+	return err
+}
+
+// LoadYAML decodes data as YAML and compiles it.
+func (c *Compiler) LoadYAML(data []byte) (*Schema, error) {
+	d, err := DecodeYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.Compile(d.Value)
+}
+
+// ValidateYAML decodes the YAML document in data and validates it. The
+// ValidationErrors have the lines and the columns of their values.
+func (s *Schema) ValidateYAML(data []byte) error {
+	d, err := DecodeYAML(data)
+	if err != nil {
+		return err
+	}
+	return d.Locate(s.Validate(d.Value))
+}
+
+// decodeDocument decodes the document of uri, which is YAML if the path of
+// uri has the extension .yaml or .yml, or JSON.
+func decodeDocument(uri string, data []byte) (interface{}, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return decodeJSON(data)
+	}
+	switch strings.ToLower(path.Ext(u.Path)) {
+	case ".yaml", ".yml":
+		d, err := DecodeYAML(data)
+		if err != nil {
+			return nil, err
+		}
+		return d.Value, nil
+	}
+	return decodeJSON(data)
+}
+
+type yamlDecoder struct {
+	scalarKeys bool
+	positions  map[string]Position
+	// aliases is the anchored nodes which are being decoded.
+	aliases map[*yaml.Node]bool
+	// budget is the number of the nodes which may still be decoded.
+	budget int
+}
+
+// countYAMLNodes counts the nodes in n without following the aliases.
+func countYAMLNodes(n *yaml.Node) int {
+	c := 1
+	for _, m := range n.Content {
+		c += countYAMLNodes(m)
+	}
+	return c
+}
+
+// spend counts n against the budget of the decoded nodes.
+func (y *yamlDecoder) spend(n *yaml.Node) error {
+	y.budget--
+	if y.budget < 0 {
+		return &YAMLError{n.Line, n.Column, YAMLExpansionError}
+	}
+	return nil
+}
+
+var yamlInteger = regexp.MustCompile(`^[-+]?[0-9]+$`)
+
+func (y *yamlDecoder) decode(n *yaml.Node, pointer string) (interface{}, error) {
+	if err := y.spend(n); err != nil {
+		return nil, err
+	}
+	if _, ok := y.positions[pointer]; !ok {
+		y.positions[pointer] = Position{n.Line, n.Column}
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return y.decode(n.Content[0], pointer)
+	case yaml.AliasNode:
+		if y.aliases[n.Alias] {
+			return nil, &YAMLError{n.Line, n.Column, YAMLAliasError}
+		}
+		y.aliases[n.Alias] = true
+		defer delete(y.aliases, n.Alias)
+		return y.decode(n.Alias, pointer)
+	case yaml.SequenceNode:
+		a := make([]interface{}, len(n.Content))
+		for i, c := range n.Content {
+			v, err := y.decode(c, pointer+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			a[i] = v
+		}
+		return a, nil
+	case yaml.MappingNode:
+		m := map[string]interface{}{}
+		if err := y.mapping(n, pointer, m, true); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return y.scalar(n)
+}
+
+// mapping decodes the pairs of the mapping n into m. The pairs of the merge
+// keys don't override the explicit pairs.
+func (y *yamlDecoder) mapping(n *yaml.Node, pointer string, m map[string]interface{}, override bool) error {
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if k.Kind == yaml.ScalarNode && k.ShortTag() == "!!merge" {
+			merges = append(merges, v)
+			continue
+		}
+		if !y.key(k) {
+			return &YAMLError{k.Line, k.Column, YAMLKeyError}
+		}
+		if _, ok := m[k.Value]; ok && !override {
+			if err := y.spend(k); err != nil {
+				return err
+			}
+			continue
+		}
+		value, err := y.decode(v, pointer+"/"+EscapePointer(k.Value))
+		if err != nil {
+			return err
+		}
+		m[k.Value] = value
+	}
+	for _, v := range merges {
+		if err := y.merge(v, pointer, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// key reports whether k is a key of the mappings, whose value is the string.
+func (y *yamlDecoder) key(k *yaml.Node) bool {
+	if k.Kind != yaml.ScalarNode {
+		return false
+	}
+	switch k.ShortTag() {
+	case "!!str":
+		return true
+	case "!!int", "!!float", "!!bool":
+		return y.scalarKeys
+	}
+	return false
+}
+
+func (y *yamlDecoder) merge(n *yaml.Node, pointer string, m map[string]interface{}) error {
+	if err := y.spend(n); err != nil {
+		return err
+	}
+	switch n.Kind {
+	case yaml.AliasNode:
+		if y.aliases[n.Alias] {
+			return &YAMLError{n.Line, n.Column, YAMLAliasError}
+		}
+		y.aliases[n.Alias] = true
+		defer delete(y.aliases, n.Alias)
+		return y.merge(n.Alias, pointer, m)
+	case yaml.MappingNode:
+		return y.mapping(n, pointer, m, false)
+	case yaml.SequenceNode:
+		for _, c := range n.Content {
+			if c.Kind == yaml.SequenceNode {
+				return &YAMLError{c.Line, c.Column, YAMLMergeError}
+			}
+			if err := y.merge(c, pointer, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &YAMLError{n.Line, n.Column, YAMLMergeError}
+}
+
+func (y *yamlDecoder) scalar(n *yaml.Node) (interface{}, error) {
+	switch n.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "!!int":
+		var i int64
+		if err := n.Decode(&i); err == nil {
+			return json.Number(strconv.FormatInt(i, 10)), nil
+		}
+		var u uint64
+		if err := n.Decode(&u); err != nil {
+			return nil, err
+		}
+		return json.Number(strconv.FormatUint(u, 10)), nil
+	case "!!float":
+		// the integers beyond 64 bits are resolved as floats, but kept as written
+		if yamlInteger.MatchString(n.Value) {
+			return json.Number(strings.TrimPrefix(n.Value, "+")), nil
+		}
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, err
+		}
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, &YAMLError{n.Line, n.Column, YAMLNumberError}
+		}
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return json.Number(s), nil
+	}
+	return n.Value, nil
+}
@@ -0,0 +1,169 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	type Case struct {
+		Message  string
+		Data     string
+		Expected interface{}
+		Error    error
+	}
+	cases := []Case{
+		{
+			Message: "scalars",
+			Data: "int: 1\nfloat: 1.5\nwhole: 1.0\nhex: 0x1F\nbig: 123456789012345678901234567890\n" +
+				"bool: true\nnil: ~\nstring: '1'\ndate: 2001-12-14\n",
+			Expected: map[string]interface{}{
+				"int":    json.Number("1"),
+				"float":  json.Number("1.5"),
+				"whole":  json.Number("1.0"),
+				"hex":    json.Number("31"),
+				"big":    json.Number("123456789012345678901234567890"),
+				"bool":   true,
+				"nil":    nil,
+				"string": "1",
+				"date":   "2001-12-14",
+			},
+		},
+		{
+			Message: "anchors and merge keys",
+			Data:    "base: &base {x: 1, y: 2}\nitems: [*base]\nmerged:\n  <<: *base\n  y: 3\n",
+			Expected: map[string]interface{}{
+				"base":   map[string]interface{}{"x": json.Number("1"), "y": json.Number("2")},
+				"items":  []interface{}{map[string]interface{}{"x": json.Number("1"), "y": json.Number("2")}},
+				"merged": map[string]interface{}{"x": json.Number("1"), "y": json.Number("3")},
+			},
+		},
+		{
+			Message: "empty document",
+			Data:    "",
+		},
+		{
+			Message: "non-string key",
+			Data:    "a:\n  1: b\n",
+			Error:   &schema.YAMLError{Line: 2, Column: 3, Err: schema.YAMLKeyError},
+		},
+		{
+			Message: "infinity",
+			Data:    "a: .inf\n",
+			Error:   &schema.YAMLError{Line: 1, Column: 4, Err: schema.YAMLNumberError},
+		},
+		{
+			Message: "merge of a scalar",
+			Data:    "a:\n  <<: 1\n",
+			Error:   &schema.YAMLError{Line: 2, Column: 7, Err: schema.YAMLMergeError},
+		},
+		{
+			Message: "billion laughs",
+			Data: "a: &a [x, x, x, x, x, x, x, x, x, x]\n" +
+				"b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]\n" +
+				"c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]\n" +
+				"d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]\n" +
+				"e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]\n" +
+				"f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]\n" +
+				"g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f, *f]\n",
+			Error: &schema.YAMLError{Line: 1, Column: 8, Err: schema.YAMLExpansionError},
+		},
+		{
+			Message: "billion laughs by merge keys",
+			Data: "a: &a {a: 1, b: 1, c: 1, d: 1, e: 1, f: 1, g: 1, h: 1, i: 1, j: 1}\n" +
+				"b: &b {<<: [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]}\n" +
+				"c: &c {<<: [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]}\n" +
+				"d: &d {<<: [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]}\n" +
+				"e: &e {<<: [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]}\n" +
+				"f: &f {<<: [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]}\n",
+			Error: &schema.YAMLError{Line: 4, Column: 41, Err: schema.YAMLExpansionError},
+		},
+		{
+			Message: "multiple documents",
+			Data:    "a: 1\n---\nb: 2\n",
+			Error:   &schema.YAMLError{Line: 2, Column: 1, Err: schema.YAMLDocumentError},
+		},
+	}
+	for _, c := range cases {
+		d, err := schema.DecodeYAML([]byte(c.Data))
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected error %v, but actual %v", c.Message, c.Error, err)
+			continue
+		}
+		if err == nil && !reflect.DeepEqual(d.Value, c.Expected) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Expected, d.Value)
+		}
+	}
+}
+
+func TestValidateYAML(t *testing.T) {
+	s, err := schema.NewCompiler().LoadYAML([]byte(`
+type: object
+properties:
+  name: {type: string, maxLength: 5}
+  tags:
+    type: array
+    items: {type: string}
+required: [name, id]
+`))
+	if err != nil {
+		t.Fatalf("Fail to LoadYAML: %s", err)
+	}
+	err = s.ValidateYAML([]byte("name: Johnny\ntags:\n  - a\n  - 1\n"))
+	type position struct {
+		InstancePath string
+		Keyword      string
+		Line, Column int
+	}
+	var actual []position
+	for _, e := range err.(schema.ValidationErrors) {
+		actual = append(actual, position{e.InstancePath, e.Keyword, e.Line, e.Column})
+	}
+	expected := []position{
+		{"", "required", 1, 1},
+		{"/name", "maxLength", 1, 7},
+		{"/tags/1", "type", 4, 5},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, but actual %v", expected, actual)
+	}
+}
+
+func TestLoadURIWithYAML(t *testing.T) {
+	compiler := schema.NewCompiler()
+	compiler.Loader = schema.MemoryLoader{
+		"https://example.com/root.yaml": []byte("properties:\n  a: {$ref: 'a.yml'}\n"),
+		"https://example.com/a.yml":     []byte("type: integer\n"),
+	}
+	s, err := compiler.LoadURI("https://example.com/root.yaml")
+	if err != nil {
+		t.Fatalf("Fail to LoadURI: %s", err)
+	}
+	if err := s.ValidateYAML([]byte("a: 1")); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if err := s.ValidateYAML([]byte("a: 1.5")); err == nil {
+		t.Errorf("expected the error of the float")
+	}
+}
+
+func TestDecodeYAMLWithScalarKeys(t *testing.T) {
+	d, err := schema.YAMLOptions{ScalarKeys: true}.Decode([]byte("200: a\n1.50: b\ntrue: c\n"))
+	if err != nil {
+		t.Fatalf("Fail to Decode: %s", err)
+	}
+	expected := map[string]interface{}{"200": "a", "1.50": "b", "true": "c"}
+	if !reflect.DeepEqual(d.Value, expected) {
+		t.Errorf("expected %v, but actual %v", expected, d.Value)
+	}
+	if p, expected := d.Positions["/1.50"], (schema.Position{Line: 2, Column: 7}); p != expected {
+		t.Errorf("expected %v, but actual %v", expected, p)
+	}
+	_, err = schema.YAMLOptions{ScalarKeys: true}.Decode([]byte("a:\n  [1]: b\n"))
+	if expected := (&schema.YAMLError{Line: 2, Column: 3, Err: schema.YAMLKeyError}); !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
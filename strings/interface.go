@@ -1,5 +1,16 @@
 package strings
 
-type Validator interface {
-	Validate(input string) error
-}
+import "github.com/go-jstmpl/go-jsvalidator/validators"
+
+// Validator validates a string.
+type Validator = validators.Validator[string]
+
+var (
+	_ Validator = EnumValidator{}
+	_ Validator = FormatValidator{}
+	_ Validator = MaxLengthValidator{}
+	_ Validator = MinLengthValidator{}
+	_ Validator = PatternValidator{}
+)
+
+var _ validators.Validator[interface{}] = ValueValidator{}
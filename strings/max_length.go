@@ -22,7 +22,7 @@ type MaxLengthValidationError struct {
 }
 
 func (m MaxLengthValidationError) Error() string {
-	return fmt.Sprintf("should be less than, or equal to, %d charactors but actual value has %d charactors",
+	return fmt.Sprintf("should be less than, or equal to, %d characters but actual value has %d characters",
 		m.Definition.MaxLength, utf8.RuneCountInString(m.Input))
 }
 
@@ -22,7 +22,7 @@ type MinLengthValidationError struct {
 }
 
 func (m MinLengthValidationError) Error() string {
-	return fmt.Sprintf("should be greater than, or equal to, %d charactors but actual value has %d charactors",
+	return fmt.Sprintf("should be greater than, or equal to, %d characters but actual value has %d characters",
 		m.Definition.MinLength, utf8.RuneCountInString(m.Input))
 }
 
@@ -12,6 +12,7 @@ var (
 
 type PatternValidator struct {
 	definition PatternValidatorDefinition
+	regexp     *regexp.Regexp
 }
 
 type PatternValidatorDefinition struct {
@@ -31,17 +32,16 @@ func NewPatternValidator(definition PatternValidatorDefinition) (PatternValidato
 	if definition.Pattern == "" {
 		return PatternValidator{}, PatternDefinitionEmptyError
 	}
-	_, err := regexp.Compile(definition.Pattern)
+	re, err := regexp.Compile(definition.Pattern)
 	if err != nil {
 		return PatternValidator{},
 			InvalidPatternError{fmt.Sprintf("invalid pattern %s: %s", definition.Pattern, err)}
 	}
-	return PatternValidator{definition}, nil
+	return PatternValidator{definition, re}, nil
 }
 
 func (p PatternValidator) Validate(input string) error {
-	ok, err := regexp.MatchString(p.definition.Pattern, input)
-	if err == nil && ok == true {
+	if p.regexp != nil && p.regexp.MatchString(input) {
 		return nil
 	}
 	return &PatternValidationError{
@@ -0,0 +1,85 @@
+package strings
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-jstmpl/go-jsvalidator/internal/nullable"
+)
+
+var ValueDefinitionNoValidatorError = errors.New("the Validator should not be nil")
+
+// ValueValidator validates the values which hold a string, such as *string,
+// sql.NullString, dbr.NullString, dbr.NullTime and the other driver.Valuer,
+// with Validator.
+type ValueValidator struct {
+	definition ValueValidatorDefinition
+}
+
+type ValueValidatorDefinition struct {
+	Validator Validator `json:"-"`
+	// Nullable reports whether nil, the nil pointers and NULL are valid.
+	// They are NullValidationError otherwise.
+	Nullable bool `json:"nullable"`
+}
+
+type NullValidationError struct {
+	Definition ValueValidatorDefinition `json:"definition"`
+	Input      interface{}              `json:"input"`
+}
+
+func (err NullValidationError) Error() string {
+	return "the value should not be null"
+}
+
+// ValueTypeError for the values which don't hold a string
+type ValueTypeError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err ValueTypeError) Error() string {
+	return fmt.Sprintf("%T doesn't hold a string", err.Input)
+}
+
+func NewValueValidator(definition ValueValidatorDefinition) (ValueValidator, error) {
+	if definition.Validator == nil {
+		return ValueValidator{}, ValueDefinitionNoValidatorError
+	}
+	return ValueValidator{definition}, nil
+}
+
+func (v ValueValidator) Validate(input interface{}) error {
+	value, null, err := nullable.Unwrap(input)
+	if err != nil {
+		return err
+	}
+	if null {
+		if v.definition.Nullable {
+			return nil
+		}
+		return &NullValidationError{v.definition, input}
+	}
+	s, ok := toString(value)
+	if !ok {
+		return &ValueTypeError{input}
+	}
+	return v.definition.Validator.Validate(s)
+}
+
+// toString returns the string of value. The time.Time is formatted in RFC 3339.
+func toString(value interface{}) (string, bool) {
+	switch t := value.(type) {
+	case string:
+		return t, true
+	case []byte:
+		return string(t), true
+	case time.Time:
+		return t.Format(time.RFC3339Nano), true
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return v.String(), true
+	}
+	return "", false
+}
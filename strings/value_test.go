@@ -0,0 +1,118 @@
+package strings_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+	"github.com/gocraft/dbr"
+)
+
+func TestNewValueValidator(t *testing.T) {
+	if _, err := strings.NewValueValidator(strings.ValueValidatorDefinition{}); err != strings.ValueDefinitionNoValidatorError {
+		t.Errorf("expected %v, but actual %v", strings.ValueDefinitionNoValidatorError, err)
+	}
+}
+
+func TestValidateOfValueValidator(t *testing.T) {
+	maxLength, err := strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: 3})
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+	format, err := strings.NewFormatValidator(strings.FormatValidatorDefinition{Format: "date-time"})
+	if err != nil {
+		t.Fatalf("Fail to construct: %s", err)
+	}
+	nullable := strings.ValueValidatorDefinition{Validator: maxLength, Nullable: true}
+	notNullable := strings.ValueValidatorDefinition{Validator: maxLength}
+	foo, foobar := "foo", "foobar"
+
+	type Case struct {
+		Message    string
+		Definition strings.ValueValidatorDefinition
+		Input      interface{}
+		Error      error
+	}
+	cases := []Case{
+		{
+			Message:    "valid string",
+			Definition: notNullable,
+			Input:      "foo",
+			Error:      nil,
+		},
+		{
+			Message:    "valid pointer",
+			Definition: notNullable,
+			Input:      &foo,
+			Error:      nil,
+		},
+		{
+			Message:    "invalid pointer",
+			Definition: notNullable,
+			Input:      &foobar,
+			Error: &strings.MaxLengthValidationError{
+				Definition: strings.MaxLengthValidatorDefinition{MaxLength: 3},
+				Input:      "foobar",
+			},
+		},
+		{
+			Message:    "valid sql.NullString",
+			Definition: notNullable,
+			Input:      sql.NullString{String: "foo", Valid: true},
+			Error:      nil,
+		},
+		{
+			Message:    "invalid dbr.NullString",
+			Definition: notNullable,
+			Input:      dbr.NewNullString("foobar"),
+			Error: &strings.MaxLengthValidationError{
+				Definition: strings.MaxLengthValidatorDefinition{MaxLength: 3},
+				Input:      "foobar",
+			},
+		},
+		{
+			Message:    "valid dbr.NullTime",
+			Definition: strings.ValueValidatorDefinition{Validator: format},
+			Input:      dbr.NewNullTime(time.Date(2016, 10, 7, 16, 58, 37, 0, time.UTC)),
+			Error:      nil,
+		},
+		{
+			Message:    "NULL with nullable",
+			Definition: nullable,
+			Input:      dbr.NewNullString(nil),
+			Error:      nil,
+		},
+		{
+			Message:    "nil pointer with nullable",
+			Definition: nullable,
+			Input:      (*string)(nil),
+			Error:      nil,
+		},
+		{
+			Message:    "NULL without nullable",
+			Definition: notNullable,
+			Input:      sql.NullString{},
+			Error: &strings.NullValidationError{
+				Definition: notNullable,
+				Input:      sql.NullString{},
+			},
+		},
+		{
+			Message:    "wrong type",
+			Definition: notNullable,
+			Input:      sql.NullInt64{Int64: 1, Valid: true},
+			Error:      &strings.ValueTypeError{Input: sql.NullInt64{Int64: 1, Valid: true}},
+		},
+	}
+	for _, c := range cases {
+		v, err := strings.NewValueValidator(c.Definition)
+		if err != nil {
+			t.Fatalf("Test with %s: fail to construct: %s", c.Message, err)
+		}
+		if err := v.Validate(c.Input); !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
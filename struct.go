@@ -0,0 +1,517 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/booleans"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/numbers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	jsstrings "github.com/go-jstmpl/go-jsvalidator/strings"
+)
+
+// TagName is the name of the struct tag read by ValidateStruct.
+const TagName = "jsonschema"
+
+var (
+	TagUnknownKeywordError = errors.New("the keyword is unknown or not applicable to the type of the field")
+	TagInvalidValueError   = errors.New("the value of the keyword is invalid")
+)
+
+// TagDefinitionError reports an invalid jsonschema tag.
+type TagDefinitionError struct {
+	Type    string `json:"type"`
+	Field   string `json:"field"`
+	Keyword string `json:"keyword"`
+	Err     error  `json:"error"`
+}
+
+func (e TagDefinitionError) Error() string {
+	return fmt.Sprintf("invalid tag of %s.%s for '%s': %s", e.Type, e.Field, e.Keyword, e.Err)
+}
+
+// InvalidStructError for ValidateStruct
+type InvalidStructError struct {
+	Input interface{} `json:"input"`
+}
+
+func (e InvalidStructError) Error() string {
+	return fmt.Sprintf("the argument of ValidateStruct should be struct or pointer struct but %T", e.Input)
+}
+
+// ValidateStruct validates the fields of v against the JSON Schema keywords
+// in their jsonschema tags, such as `jsonschema:"required,maxLength=10,format=email"`.
+//
+// The keywords are evaluated by the validators of the strings, integers,
+// numbers, booleans and arrays packages depending on the type of the field.
+// The enum values are separated by "|", and a comma in a value is escaped by "\".
+// The validators are built once per struct type.
+//
+// Nested structs, and structs in slices, arrays and pointers are validated recursively.
+// The returned error is schema.ValidationErrors whose InstancePath is the
+// JSON Pointer of the field made of the names in json tags, and SchemaPath
+// is the Go name of the field such as "User.Email".
+func ValidateStruct(v interface{}) error {
+	rv, ok := convertToConcreteValue(reflect.ValueOf(v))
+	if !ok || rv.Kind() != reflect.Struct {
+		return &InvalidStructError{v}
+	}
+	var errs schema.ValidationErrors
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+type structValidator struct {
+	fields []fieldValidator
+	err    error
+}
+
+type fieldValidator struct {
+	index      int
+	name       string
+	goName     string
+	inline     bool
+	required   bool
+	validators []keywordValidator
+}
+
+type keywordValidator struct {
+	keyword  string
+	validate func(reflect.Value) error
+}
+
+var structValidators sync.Map
+
+// structValidatorOf returns the validator of the struct type t.
+func structValidatorOf(t reflect.Type) (*structValidator, error) {
+	if sv, ok := structValidators.Load(t); ok {
+		return sv.(*structValidator), sv.(*structValidator).err
+	}
+	sv := newStructValidator(t)
+	structValidators.Store(t, sv)
+	return sv, sv.err
+}
+
+func newStructValidator(t reflect.Type) *structValidator {
+	sv := &structValidator{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name, inline := jsonName(f)
+		if name == "-" {
+			continue
+		}
+		fv := fieldValidator{
+			index:  i,
+			name:   name,
+			goName: f.Name,
+			inline: inline,
+		}
+		keywords, err := parseTag(f.Tag.Get(TagName))
+		if err != nil {
+			sv.err = &TagDefinitionError{t.Name(), f.Name, "", err}
+			return sv
+		}
+		for _, k := range keywords {
+			if k.key == "required" {
+				fv.required = true
+				continue
+			}
+			validate, err := newKeywordValidator(f.Type, k.key, k.value)
+			if err != nil {
+				sv.err = &TagDefinitionError{t.Name(), f.Name, k.key, err}
+				return sv
+			}
+			fv.validators = append(fv.validators, keywordValidator{k.key, validate})
+		}
+		sv.fields = append(sv.fields, fv)
+	}
+	return sv
+}
+
+// jsonName returns the name of the field in JSON.
+// The inline reports whether the fields of the embedded struct are promoted.
+func jsonName(f reflect.StructField) (name string, inline bool) {
+	tag := f.Tag.Get("json")
+	if i := strings.Index(tag, ","); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag != "" {
+		return tag, false
+	}
+	t := f.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if f.Anonymous && t.Kind() == reflect.Struct {
+		return "", true
+	}
+	return f.Name, false
+}
+
+func validateStruct(v reflect.Value, path string, errs *schema.ValidationErrors) error {
+	t := v.Type()
+	sv, err := structValidatorOf(t)
+	if err != nil {
+		return err
+	}
+	for _, fv := range sv.fields {
+		f := v.Field(fv.index)
+		p := path
+		if !fv.inline {
+			p += "/" + escapePointer(fv.name)
+		}
+		c, ok := convertToConcreteValue(f)
+		if fv.required && (!ok || c.CanInterface() && !isValid(c.Interface())) {
+			var input interface{}
+			if v.CanInterface() {
+				input = v.Interface()
+			}
+			*errs = append(*errs, &schema.ValidationError{
+				InstancePath: p,
+				SchemaPath:   t.Name() + "." + fv.goName,
+				Keyword:      "required",
+				Err: &RequiredValidationError{
+					Input:      input,
+					Definition: RequiredValidatorDefinition{Required: []string{fv.goName}},
+				},
+			})
+		}
+		if !ok {
+			continue
+		}
+		for _, kv := range fv.validators {
+			if err := kv.validate(c); err != nil {
+				*errs = append(*errs, &schema.ValidationError{
+					InstancePath: p,
+					SchemaPath:   t.Name() + "." + fv.goName,
+					Keyword:      kv.keyword,
+					Err:          err,
+				})
+			}
+		}
+		if err := validateElements(c, p, errs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateElements validates the structs in v recursively.
+func validateElements(v reflect.Value, path string, errs *schema.ValidationErrors) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		return validateStruct(v, path, errs)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return validateElements(v.Elem(), path, errs)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateElements(v.Index(i), path+"/"+strconv.Itoa(i), errs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type tagKeyword struct {
+	key   string
+	value string
+}
+
+// parseTag parses the comma separated keywords such as "required,maxLength=10".
+func parseTag(tag string) ([]tagKeyword, error) {
+	var keywords []tagKeyword
+	var b []byte
+	for i := 0; i <= len(tag); i++ {
+		if i < len(tag) && tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',' {
+			b = append(b, ',')
+			i++
+			continue
+		}
+		if i < len(tag) && tag[i] != ',' {
+			b = append(b, tag[i])
+			continue
+		}
+		s := strings.TrimSpace(string(b))
+		b = b[:0]
+		if s == "" {
+			continue
+		}
+		k := tagKeyword{key: s}
+		if j := strings.Index(s, "="); j >= 0 {
+			k.key, k.value = s[:j], s[j+1:]
+		} else if s != "required" {
+			return nil, TagInvalidValueError
+		}
+		keywords = append(keywords, k)
+	}
+	return keywords, nil
+}
+
+// newKeywordValidator returns the function which validates a value of type t against the keyword.
+func newKeywordValidator(t reflect.Type, key, value string) (func(reflect.Value) error, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if name, ok := nullTypes[t]; ok {
+		return newNullValidator(name, key, value)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		va, err := newStringValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return va.Validate(v.String())
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		va, err := newIntegerValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		// the unsigned integers beyond int are compared exactly as big numbers
+		vb, err := newBigIntegerValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			switch v.Kind() {
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				u := v.Uint()
+				if u > math.MaxInt {
+					return vb.Validate(u)
+				}
+				return va.Validate(int(u))
+			}
+			return va.Validate(int(v.Int()))
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		va, err := newNumberValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return va.Validate(v.Float())
+		}, nil
+	case reflect.Bool:
+		va, err := newBooleanValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return va.Validate(v.Bool())
+		}, nil
+	case reflect.Slice:
+		return newArrayValidator(key, value)
+	}
+	return nil, TagUnknownKeywordError
+}
+
+// newNullValidator returns the function which validates a value of the
+// null type such as dbr.NullString, whose JSON type is name. The NULL values
+// are valid, and are reported by required.
+func newNullValidator(name, key, value string) (func(reflect.Value) error, error) {
+	var va interface {
+		Validate(interface{}) error
+	}
+	switch name {
+	case "string":
+		v, err := newStringValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		va, _ = jsstrings.NewValueValidator(jsstrings.ValueValidatorDefinition{Validator: v, Nullable: true})
+	case "integer":
+		v, err := newIntegerValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		va, _ = integers.NewValueValidator(integers.ValueValidatorDefinition{Validator: v, Nullable: true})
+	case "number":
+		v, err := newNumberValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		va, _ = numbers.NewValueValidator(numbers.ValueValidatorDefinition{Validator: v, Nullable: true})
+	case "boolean":
+		v, err := newBooleanValidator(key, value)
+		if err != nil {
+			return nil, err
+		}
+		va, _ = booleans.NewValueValidator(booleans.ValueValidatorDefinition{Validator: v, Nullable: true})
+	}
+	return func(v reflect.Value) error {
+		return va.Validate(v.Interface())
+	}, nil
+}
+
+func newStringValidator(key, value string) (jsstrings.Validator, error) {
+	switch key {
+	case "maxLength":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return jsstrings.NewMaxLengthValidator(jsstrings.MaxLengthValidatorDefinition{MaxLength: n})
+	case "minLength":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return jsstrings.NewMinLengthValidator(jsstrings.MinLengthValidatorDefinition{MinLength: n})
+	case "pattern":
+		return jsstrings.NewPatternValidator(jsstrings.PatternValidatorDefinition{Pattern: value})
+	case "format":
+		return jsstrings.NewFormatValidator(jsstrings.FormatValidatorDefinition{Format: value})
+	case "enum":
+		return jsstrings.NewEnumValidator(jsstrings.EnumValidatorDefinition{Enum: strings.Split(value, "|")})
+	}
+	return nil, TagUnknownKeywordError
+}
+
+func newIntegerValidator(key, value string) (integers.Validator, error) {
+	switch key {
+	case "maximum", "exclusiveMaximum":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return integers.NewMaximumValidator(integers.MaximumValidatorDefinition{Maximum: n, Exclusive: key == "exclusiveMaximum"})
+	case "minimum", "exclusiveMinimum":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return integers.NewMinimumValidator(integers.MinimumValidatorDefinition{Minimum: n, Exclusive: key == "exclusiveMinimum"})
+	case "enum":
+		var enum []int
+		for _, s := range strings.Split(value, "|") {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, TagInvalidValueError
+			}
+			enum = append(enum, n)
+		}
+		return integers.NewEnumValidator(integers.EnumValidatorDefinition{Enum: enum})
+	}
+	return nil, TagUnknownKeywordError
+}
+
+// newBigIntegerValidator returns the validator of the keyword for the
+// unsigned integers which don't fit in int.
+func newBigIntegerValidator(key, value string) (bignumbers.Validator, error) {
+	var numbers []json.Number
+	for _, s := range strings.Split(value, "|") {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		numbers = append(numbers, json.Number(strconv.Itoa(n)))
+	}
+	switch key {
+	case "maximum", "exclusiveMaximum":
+		return bignumbers.NewMaximumValidator(bignumbers.MaximumValidatorDefinition{Maximum: numbers[0], Exclusive: key == "exclusiveMaximum"})
+	case "minimum", "exclusiveMinimum":
+		return bignumbers.NewMinimumValidator(bignumbers.MinimumValidatorDefinition{Minimum: numbers[0], Exclusive: key == "exclusiveMinimum"})
+	case "enum":
+		return bignumbers.NewEnumValidator(bignumbers.EnumValidatorDefinition{Enum: numbers})
+	}
+	return nil, TagUnknownKeywordError
+}
+
+func newNumberValidator(key, value string) (numbers.Validator, error) {
+	switch key {
+	case "maximum", "exclusiveMaximum":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return numbers.NewMaximumValidator(numbers.MaximumValidatorDefinition{Maximum: n, Exclusive: key == "exclusiveMaximum"})
+	case "minimum", "exclusiveMinimum":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		return numbers.NewMinimumValidator(numbers.MinimumValidatorDefinition{Minimum: n, Exclusive: key == "exclusiveMinimum"})
+	case "enum":
+		var enum []float64
+		for _, s := range strings.Split(value, "|") {
+			n, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, TagInvalidValueError
+			}
+			enum = append(enum, n)
+		}
+		return numbers.NewEnumValidator(numbers.EnumValidatorDefinition{Enum: enum})
+	}
+	return nil, TagUnknownKeywordError
+}
+
+func newBooleanValidator(key, value string) (booleans.Validator, error) {
+	if key != "enum" {
+		return nil, TagUnknownKeywordError
+	}
+	var enum []bool
+	for _, s := range strings.Split(value, "|") {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, TagInvalidValueError
+		}
+		enum = append(enum, b)
+	}
+	return booleans.NewEnumValidator(booleans.EnumValidatorDefinition{Enum: enum})
+}
+
+func newArrayValidator(key, value string) (func(reflect.Value) error, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, TagInvalidValueError
+	}
+	switch key {
+	case "maxItems":
+		va, err := arrays.NewMaxItemsValidator(arrays.MaxItemsValidatorDefinition{MaxItems: n})
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return va.Validate(v.Interface())
+		}, nil
+	case "minItems":
+		va, err := arrays.NewMinItemsValidator(arrays.MinItemsValidatorDefinition{MinItems: n})
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) error {
+			return va.Validate(v.Interface())
+		}, nil
+	}
+	return nil, TagUnknownKeywordError
+}
+
+// escapePointer escapes a reference token of JSON Pointer.
+func escapePointer(token string) string {
+	token = strings.Replace(token, "~", "~0", -1)
+	return strings.Replace(token, "/", "~1", -1)
+}
@@ -0,0 +1,316 @@
+package validator_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/schema"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+	"github.com/gocraft/dbr"
+)
+
+type Address struct {
+	Zip  string `json:"zip" jsonschema:"required,pattern=^\\d{3}-\\d{4}$"`
+	City string `json:"city,omitempty" jsonschema:"maxLength=5"`
+}
+
+type Base struct {
+	ID int `json:"id" jsonschema:"minimum=1"`
+}
+
+type User struct {
+	Base
+	Name      string    `json:"name" jsonschema:"required,minLength=1,maxLength=10"`
+	Email     *string   `json:"email" jsonschema:"format=email"`
+	Age       uint8     `json:"age" jsonschema:"maximum=150"`
+	Score     float64   `json:"score" jsonschema:"exclusiveMinimum=0,maximum=1"`
+	Role      string    `json:"role" jsonschema:"enum=admin|member"`
+	Tags      []string  `json:"tags" jsonschema:"maxItems=2"`
+	Address   Address   `json:"address"`
+	Addresses []Address `json:"addresses"`
+	Ignored   string    `json:"-" jsonschema:"minLength=100"`
+	Note      string    `jsonschema:"pattern=^[a-z]{1\\,3}$"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	email := "foo@example.com"
+	invalidEmail := "foo"
+	valid := User{
+		Base:      Base{ID: 1},
+		Name:      "foo",
+		Email:     &email,
+		Age:       20,
+		Score:     0.5,
+		Role:      "admin",
+		Tags:      []string{"a"},
+		Address:   Address{Zip: "123-4567", City: "Tokyo"},
+		Addresses: []Address{{Zip: "123-4567"}},
+		Note:      "abc",
+	}
+
+	type Case struct {
+		Message string
+		Input   func(u *User)
+		Errors  []string
+	}
+	cases := []Case{
+		{
+			Message: "valid struct",
+			Input:   func(u *User) {},
+		},
+		{
+			Message: "nil pointer",
+			Input:   func(u *User) { u.Email = nil },
+		},
+		{
+			Message: "missing required",
+			Input:   func(u *User) { u.Name = "" },
+			Errors:  []string{"/name required", "/name minLength"},
+		},
+		{
+			Message: "invalid embedded struct",
+			Input:   func(u *User) { u.ID = 0 },
+			Errors:  []string{"/id minimum"},
+		},
+		{
+			Message: "invalid pointer",
+			Input:   func(u *User) { u.Email = &invalidEmail },
+			Errors:  []string{"/email format"},
+		},
+		{
+			Message: "invalid numbers",
+			Input:   func(u *User) { u.Age = 151; u.Score = 0 },
+			Errors:  []string{"/age maximum", "/score exclusiveMinimum"},
+		},
+		{
+			Message: "invalid enum and slice",
+			Input:   func(u *User) { u.Role = "guest"; u.Tags = []string{"a", "b", "c"} },
+			Errors:  []string{"/role enum", "/tags maxItems"},
+		},
+		{
+			Message: "invalid nested struct",
+			Input:   func(u *User) { u.Address.Zip = "" },
+			Errors:  []string{"/address/zip required", "/address/zip pattern"},
+		},
+		{
+			Message: "invalid struct in slice",
+			Input:   func(u *User) { u.Addresses = append(u.Addresses, Address{Zip: "1", City: "Sapporo"}) },
+			Errors:  []string{"/addresses/1/zip pattern", "/addresses/1/city maxLength"},
+		},
+		{
+			Message: "pattern with escaped comma",
+			Input:   func(u *User) { u.Note = "abcd" },
+			Errors:  []string{"/Note pattern"},
+		},
+	}
+
+	for _, c := range cases {
+		u := valid
+		u.Addresses = append([]Address{}, valid.Addresses...)
+		c.Input(&u)
+		var actual []string
+		err := validator.ValidateStruct(&u)
+		if err != nil {
+			errs, ok := err.(schema.ValidationErrors)
+			if !ok {
+				t.Errorf("Test with %s: unexpected error %v", c.Message, err)
+				continue
+			}
+			for _, e := range errs {
+				actual = append(actual, e.InstancePath+" "+e.Keyword)
+			}
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+}
+
+func TestValidateStructWithNullTypes(t *testing.T) {
+	type Record struct {
+		Name  dbr.NullString   `json:"name" jsonschema:"required,maxLength=3"`
+		Note  sql.NullString   `json:"note" jsonschema:"minLength=2"`
+		Count dbr.NullInt64    `json:"count" jsonschema:"maximum=10"`
+		Rate  *sql.NullFloat64 `json:"rate" jsonschema:"minimum=0"`
+		Flag  dbr.NullBool     `json:"flag" jsonschema:"enum=true"`
+	}
+
+	type Case struct {
+		Message string
+		Input   Record
+		Errors  []string
+	}
+	cases := []Case{
+		{
+			Message: "valid values",
+			Input: Record{
+				Name:  dbr.NewNullString("foo"),
+				Note:  sql.NullString{String: "ok", Valid: true},
+				Count: dbr.NewNullInt64(10),
+				Rate:  &sql.NullFloat64{Float64: 0.5, Valid: true},
+				Flag:  dbr.NewNullBool(true),
+			},
+		},
+		{
+			Message: "NULL values",
+			Input:   Record{Name: dbr.NewNullString("foo")},
+		},
+		{
+			Message: "NULL required value",
+			Input:   Record{},
+			Errors:  []string{"/name required"},
+		},
+		{
+			Message: "invalid values",
+			Input: Record{
+				Name:  dbr.NewNullString("foobar"),
+				Note:  sql.NullString{String: "a", Valid: true},
+				Count: dbr.NewNullInt64(11),
+				Rate:  &sql.NullFloat64{Float64: -1, Valid: true},
+				Flag:  dbr.NewNullBool(false),
+			},
+			Errors: []string{"/name maxLength", "/note minLength", "/count maximum", "/rate minimum", "/flag enum"},
+		},
+	}
+	for _, c := range cases {
+		var actual []string
+		if err := validator.ValidateStruct(c.Input); err != nil {
+			errs, ok := err.(schema.ValidationErrors)
+			if !ok {
+				t.Errorf("Test with %s: unexpected error %v", c.Message, err)
+				continue
+			}
+			for _, e := range errs {
+				actual = append(actual, e.InstancePath+" "+e.Keyword)
+			}
+		}
+		if !reflect.DeepEqual(actual, c.Errors) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Errors, actual)
+		}
+	}
+}
+
+func TestValidateStructWithValidatorErrors(t *testing.T) {
+	type Sample struct {
+		Name  string `json:"name" jsonschema:"maxLength=2"`
+		Count int    `jsonschema:"maximum=10"`
+	}
+	err := validator.ValidateStruct(Sample{Name: "foo", Count: 11})
+	expected := schema.ValidationErrors{
+		{
+			InstancePath: "/name",
+			SchemaPath:   "Sample.Name",
+			Keyword:      "maxLength",
+			Err: &strings.MaxLengthValidationError{
+				Definition: strings.MaxLengthValidatorDefinition{MaxLength: 2},
+				Input:      "foo",
+			},
+		},
+		{
+			InstancePath: "/Count",
+			SchemaPath:   "Sample.Count",
+			Keyword:      "maximum",
+			Err: &integers.MaximumValidationError{
+				Definition: integers.MaximumValidatorDefinition{Maximum: 10},
+				Input:      11,
+			},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestValidateStructWithLargeUnsignedIntegers(t *testing.T) {
+	type Sample struct {
+		Max  uint64 `json:"max" jsonschema:"maximum=10"`
+		Min  uint64 `json:"min" jsonschema:"minimum=0"`
+		Enum uint   `json:"enum" jsonschema:"enum=1|2"`
+	}
+	err := validator.ValidateStruct(Sample{Max: 1 << 63, Min: math.MaxUint64, Enum: 1<<63 + 1})
+	expected := schema.ValidationErrors{
+		{
+			InstancePath: "/max",
+			SchemaPath:   "Sample.Max",
+			Keyword:      "maximum",
+			Err: &bignumbers.MaximumValidationError{
+				Definition: bignumbers.MaximumValidatorDefinition{Maximum: "10"},
+				Input:      uint64(1 << 63),
+			},
+		},
+		{
+			InstancePath: "/enum",
+			SchemaPath:   "Sample.Enum",
+			Keyword:      "enum",
+			Err: &bignumbers.EnumValidationError{
+				Definition: bignumbers.EnumValidatorDefinition{Enum: []json.Number{"1", "2"}},
+				Input:      uint64(1<<63 + 1),
+			},
+		},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestValidateStructWithInvalidTag(t *testing.T) {
+	type UnknownKeyword struct {
+		Count int `jsonschema:"maxLength=1"`
+	}
+	type InvalidValue struct {
+		Name string `jsonschema:"maxLength=a"`
+	}
+	type NegativeLength struct {
+		Name string `jsonschema:"maxLength=-1"`
+	}
+	type Flag struct {
+		Name string `jsonschema:"unique"`
+	}
+
+	type Case struct {
+		Message string
+		Input   interface{}
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "keyword not applicable to the type",
+			Input:   UnknownKeyword{},
+			Error:   &validator.TagDefinitionError{Type: "UnknownKeyword", Field: "Count", Keyword: "maxLength", Err: validator.TagUnknownKeywordError},
+		},
+		{
+			Message: "invalid value",
+			Input:   InvalidValue{},
+			Error:   &validator.TagDefinitionError{Type: "InvalidValue", Field: "Name", Keyword: "maxLength", Err: validator.TagInvalidValueError},
+		},
+		{
+			Message: "error of validator definition",
+			Input:   NegativeLength{},
+			Error:   &validator.TagDefinitionError{Type: "NegativeLength", Field: "Name", Keyword: "maxLength", Err: strings.MaxLengthDefinitionNoLengthError},
+		},
+		{
+			Message: "unknown flag",
+			Input:   &Flag{},
+			Error:   &validator.TagDefinitionError{Type: "Flag", Field: "Name", Err: validator.TagInvalidValueError},
+		},
+		{
+			Message: "not struct",
+			Input:   "foo",
+			Error:   &validator.InvalidStructError{Input: "foo"},
+		},
+	}
+	for _, c := range cases {
+		// the error is cached per type, so it is the same for the second time
+		for i := 0; i < 2; i++ {
+			if err := validator.ValidateStruct(c.Input); !reflect.DeepEqual(err, c.Error) {
+				t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+			}
+		}
+	}
+}
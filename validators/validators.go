@@ -0,0 +1,130 @@
+// Package validators composes the validators of the strings, integers,
+// numbers, booleans, bignumbers and arrays packages, whose Validator
+// interfaces are the instances of Validator of this package.
+package validators
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+// Validator validates an input of the type T.
+type Validator[T any] interface {
+	Validate(input T) error
+}
+
+// Func is a function which validates an input as Validator.
+type Func[T any] func(input T) error
+
+func (f Func[T]) Validate(input T) error {
+	return f(input)
+}
+
+// Errors is the errors of the validators of All.
+type Errors []error
+
+func (errs Errors) Error() string {
+	var b bytes.Buffer
+	for i, err := range errs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+type AnyValidationError struct {
+	Errors []error `json:"errors"`
+}
+
+func (err AnyValidationError) Error() string {
+	return "input value doesn't match any of the validators"
+}
+
+type NotValidationError struct {
+	Input interface{} `json:"input"`
+}
+
+func (err NotValidationError) Error() string {
+	return fmt.Sprintf("input value %v should not match the validator", err.Input)
+}
+
+// TypeError reports an input of Of which is not of the type of the validator.
+type TypeError struct {
+	Input interface{} `json:"input"`
+	Type  string      `json:"type"`
+}
+
+func (err TypeError) Error() string {
+	return fmt.Sprintf("%T should be %s", err.Input, err.Type)
+}
+
+// All returns the validator which validates the input with all of vs,
+// and returns Errors of the validators which fail.
+func All[T any](vs ...Validator[T]) Validator[T] {
+	return Func[T](func(input T) error {
+		var errs Errors
+		for _, v := range vs {
+			if err := v.Validate(input); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) == 0 {
+			return nil
+		}
+		return errs
+	})
+}
+
+// Any returns the validator which succeeds when any of vs succeeds, and
+// returns AnyValidationError otherwise.
+func Any[T any](vs ...Validator[T]) Validator[T] {
+	return Func[T](func(input T) error {
+		errs := make([]error, 0, len(vs))
+		for _, v := range vs {
+			err := v.Validate(input)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		return &AnyValidationError{errs}
+	})
+}
+
+// Not returns the validator which fails with NotValidationError when v succeeds.
+func Not[T any](v Validator[T]) Validator[T] {
+	return Func[T](func(input T) error {
+		if v.Validate(input) == nil {
+			return &NotValidationError{input}
+		}
+		return nil
+	})
+}
+
+// Map returns the validator of T which validates the input converted by
+// convert with v. The error of convert is returned as it is.
+func Map[T, U any](v Validator[U], convert func(T) (U, error)) Validator[T] {
+	return Func[T](func(input T) error {
+		u, err := convert(input)
+		if err != nil {
+			return err
+		}
+		return v.Validate(u)
+	})
+}
+
+// Of returns the validator of any input which validates the inputs of T
+// with v, so that the validators of the different types are stored in a
+// collection. The other inputs are TypeError.
+func Of[T any](v Validator[T]) Validator[interface{}] {
+	return Map(v, func(input interface{}) (T, error) {
+		t, ok := input.(T)
+		if !ok {
+			return t, &TypeError{input, reflect.TypeOf((*T)(nil)).Elem().String()}
+		}
+		return t, nil
+	})
+}
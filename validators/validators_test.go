@@ -0,0 +1,182 @@
+package validators_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-jstmpl/go-jsvalidator/arrays"
+	"github.com/go-jstmpl/go-jsvalidator/bignumbers"
+	"github.com/go-jstmpl/go-jsvalidator/integers"
+	"github.com/go-jstmpl/go-jsvalidator/strings"
+	"github.com/go-jstmpl/go-jsvalidator/validators"
+)
+
+func mustMaxLength(t *testing.T, n int) strings.MaxLengthValidator {
+	v, err := strings.NewMaxLengthValidator(strings.MaxLengthValidatorDefinition{MaxLength: n})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func mustPattern(t *testing.T, pattern string) strings.PatternValidator {
+	v, err := strings.NewPatternValidator(strings.PatternValidatorDefinition{Pattern: pattern})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestAll(t *testing.T) {
+	maxLength := mustMaxLength(t, 3)
+	pattern := mustPattern(t, "^[a-z]+$")
+	v := validators.All[string](maxLength, pattern)
+
+	type Case struct {
+		Message string
+		Input   string
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "valid",
+			Input:   "abc",
+		},
+		{
+			Message: "an error",
+			Input:   "abcd",
+			Error: validators.Errors{
+				maxLength.Validate("abcd"),
+			},
+		},
+		{
+			Message: "all errors",
+			Input:   "ABCD",
+			Error: validators.Errors{
+				maxLength.Validate("ABCD"),
+				pattern.Validate("ABCD"),
+			},
+		},
+	}
+	for _, c := range cases {
+		err := v.Validate(c.Input)
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestAny(t *testing.T) {
+	maxLength := mustMaxLength(t, 3)
+	pattern := mustPattern(t, "^[a-z]+$")
+	v := validators.Any[string](maxLength, pattern)
+
+	type Case struct {
+		Message string
+		Input   string
+		Error   error
+	}
+	cases := []Case{
+		{
+			Message: "first",
+			Input:   "ABC",
+		},
+		{
+			Message: "second",
+			Input:   "abcd",
+		},
+		{
+			Message: "none",
+			Input:   "ABCD",
+			Error: &validators.AnyValidationError{
+				Errors: []error{maxLength.Validate("ABCD"), pattern.Validate("ABCD")},
+			},
+		},
+	}
+	for _, c := range cases {
+		err := v.Validate(c.Input)
+		if !reflect.DeepEqual(err, c.Error) {
+			t.Errorf("Test with %s: expected %v, but actual %v", c.Message, c.Error, err)
+		}
+	}
+}
+
+func TestNot(t *testing.T) {
+	minimum, err := integers.NewMinimumValidator(integers.MinimumValidatorDefinition{Minimum: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := validators.Not[int](minimum)
+	if err := v.Validate(9); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if err, expected := v.Validate(10), (&validators.NotValidationError{Input: 10}); !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}
+
+func TestMapAndFunc(t *testing.T) {
+	even := validators.Func[int](func(input int) error {
+		if input%2 != 0 {
+			return &validators.NotValidationError{Input: input}
+		}
+		return nil
+	})
+	length := validators.Map[string, int](even, func(s string) (int, error) {
+		return len(s), nil
+	})
+	if err := length.Validate("ab"); err != nil {
+		t.Errorf("expected no error, but actual %v", err)
+	}
+	if err := length.Validate("abc"); err == nil {
+		t.Errorf("expected the error of the odd length")
+	}
+}
+
+func TestOf(t *testing.T) {
+	maxItems, err := arrays.NewMaxItemsValidator(arrays.MaxItemsValidatorDefinition{MaxItems: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	maximum, err := bignumbers.NewMaximumValidator(bignumbers.MaximumValidatorDefinition{Maximum: "9007199254740993"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the validators of the different types in a collection
+	vs := []validators.Validator[interface{}]{
+		validators.Of[string](mustMaxLength(t, 3)),
+		maxItems,
+		maximum,
+	}
+
+	type Case struct {
+		Message string
+		Inputs  []interface{}
+		Errors  []bool
+	}
+	cases := []Case{
+		{
+			Message: "valid",
+			Inputs:  []interface{}{"abc", []int{1}, json.Number("9007199254740993")},
+			Errors:  []bool{false, false, false},
+		},
+		{
+			Message: "invalid",
+			Inputs:  []interface{}{"abcd", []int{1, 2}, json.Number("9007199254740994")},
+			Errors:  []bool{true, true, true},
+		},
+	}
+	for _, c := range cases {
+		for i, v := range vs {
+			if err := v.Validate(c.Inputs[i]); (err != nil) != c.Errors[i] {
+				t.Errorf("Test with %s: expected the error %t of the validator %d, but actual %v", c.Message, c.Errors[i], i, err)
+			}
+		}
+	}
+
+	err = vs[0].Validate(1)
+	if expected := (&validators.TypeError{Input: 1, Type: "string"}); !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %v, but actual %v", expected, err)
+	}
+}